@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/address_lookup_table"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+func (c *Client) GetAddressLookupTable(ctx context.Context, base58Addr string) (address_lookup_table.AddressLookupTable, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, base58Addr)
+	if err != nil {
+		return address_lookup_table.AddressLookupTable{}, err
+	}
+	return address_lookup_table.DeserializeLookupTable(accountInfo.Data, accountInfo.Owner)
+}
+
+// GetAddressLookupTableAccounts fetches lookup tables in one request, it fails if
+// any of them doesn't exist
+func (c *Client) GetAddressLookupTableAccounts(ctx context.Context, keys []common.PublicKey) ([]types.AddressLookupTableAccount, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	addrs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		addrs = append(addrs, key.ToBase58())
+	}
+	accountInfos, err := c.GetMultipleAccounts(ctx, addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]types.AddressLookupTableAccount, 0, len(keys))
+	for i, accountInfo := range accountInfos {
+		if accountInfo.Owner == (common.PublicKey{}) {
+			return nil, fmt.Errorf("address lookup table %v not found", addrs[i])
+		}
+		table, err := address_lookup_table.DeserializeLookupTable(accountInfo.Data, accountInfo.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize address lookup table %v, err: %v", addrs[i], err)
+		}
+		tables = append(tables, types.AddressLookupTableAccount{
+			Key:       keys[i],
+			Addresses: table.Addresses,
+		})
+	}
+	return tables, nil
+}
+
+// DecompileInstructions decompiles a legacy or v0 message, it fetches lookup tables
+// the message uses
+func (c *Client) DecompileInstructions(ctx context.Context, message types.Message) ([]types.Instruction, error) {
+	keys := make([]common.PublicKey, 0, len(message.AddressLookupTables))
+	for _, lookup := range message.AddressLookupTables {
+		keys = append(keys, lookup.AccountKey)
+	}
+	tables, err := c.GetAddressLookupTableAccounts(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address lookup tables, err: %v", err)
+	}
+	return message.DecompileInstructionsWithAddressLookupTables(tables)
+}
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/address_lookup_table"
+	"github.com/blocto/solana-go-sdk/program/sysvar"
+	"github.com/blocto/solana-go-sdk/rpc"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+var (
+	ErrAddressLookupTableFull           = errors.New("address lookup table is full")
+	ErrAddressLookupTableNotDeactivated = errors.New("address lookup table is not deactivated")
+)
+
+// AddressLookupTableManager creates, extends, deactivates and closes lookup tables.
+// every transaction is paid by the payer and signed by the authority.
+type AddressLookupTableManager struct {
+	client    *Client
+	payer     types.Signer
+	authority types.Signer
+
+	// Config is used to send and confirm every transaction
+	Config SendAndConfirmTransactionConfig
+	// Commitment is used to read tables and slots, default: confirmed
+	Commitment rpc.Commitment
+	// PollInterval is how often WaitUntilActive checks the slot, default: 400ms
+	PollInterval time.Duration
+}
+
+func NewAddressLookupTableManager(c *Client, payer, authority types.Signer) *AddressLookupTableManager {
+	return &AddressLookupTableManager{
+		client:    c,
+		payer:     payer,
+		authority: authority,
+	}
+}
+
+// Create creates a table derived from the authority and a recent slot
+func (m *AddressLookupTableManager) Create(ctx context.Context) (common.PublicKey, error) {
+	// a finalized slot is still in the slot hashes
+	recentSlot, err := m.client.GetSlotWithConfig(ctx, GetSlotConfig{Commitment: rpc.CommitmentFinalized})
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to get recent slot, err: %w", err)
+	}
+	table, bump := address_lookup_table.DeriveLookupTableAddress(m.authority.Public(), recentSlot)
+	err = m.send(ctx, []types.Instruction{
+		address_lookup_table.CreateLookupTable(address_lookup_table.CreateLookupTableParams{
+			LookupTable: table,
+			Authority:   m.authority.Public(),
+			Payer:       m.payer.Public(),
+			RecentSlot:  recentSlot,
+			BumpSeed:    bump,
+		}),
+	})
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return table, nil
+}
+
+// Extend adds addresses which aren't in the table yet. it sends as many transactions as
+// needed, each one carries as many addresses as fit.
+func (m *AddressLookupTableManager) Extend(ctx context.Context, table common.PublicKey, addresses []common.PublicKey) error {
+	state, _, err := m.getTable(ctx, table)
+	if err != nil {
+		return err
+	}
+	existing := make(map[common.PublicKey]struct{}, len(state.Addresses))
+	for _, address := range state.Addresses {
+		existing[address] = struct{}{}
+	}
+	newAddresses := make([]common.PublicKey, 0, len(addresses))
+	for _, address := range addresses {
+		if _, ok := existing[address]; ok {
+			continue
+		}
+		existing[address] = struct{}{}
+		newAddresses = append(newAddresses, address)
+	}
+	if total := len(state.Addresses) + len(newAddresses); total > int(address_lookup_table.LOOKUP_TABLE_MAX_ADDRESSES) {
+		return fmt.Errorf("%w, max: %v, got: %v", ErrAddressLookupTableFull, address_lookup_table.LOOKUP_TABLE_MAX_ADDRESSES, total)
+	}
+
+	for len(newAddresses) > 0 {
+		n, err := m.extendChunkSize(table, newAddresses)
+		if err != nil {
+			return err
+		}
+		if err := m.send(ctx, []types.Instruction{m.extend(table, newAddresses[:n])}); err != nil {
+			return err
+		}
+		newAddresses = newAddresses[n:]
+	}
+	return nil
+}
+
+// WaitUntilActive waits until every address of the table can be looked up. addresses
+// extended at a slot become active from the next slot.
+func (m *AddressLookupTableManager) WaitUntilActive(ctx context.Context, table common.PublicKey) error {
+	pollInterval := m.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 400 * time.Millisecond
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		state, slot, err := m.getTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if len(state.ActiveAddresses(slot)) == len(state.Addresses) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status reads the table and the slot hashes sysvar at the same slot
+func (m *AddressLookupTableManager) Status(ctx context.Context, table common.PublicKey) (address_lookup_table.LookupTableStatus, error) {
+	res, err := m.client.GetMultipleAccountsAndContextWithConfig(
+		ctx,
+		[]string{table.ToBase58(), common.SysVarSlotHashesPubkey.ToBase58()},
+		GetMultipleAccountsConfig{Commitment: m.commitment()},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get address lookup table and slot hashes, err: %w", err)
+	}
+	if len(res.Value) != 2 {
+		return 0, fmt.Errorf("unexpected number of accounts, expected: 2, got: %v", len(res.Value))
+	}
+	state, err := deserializeAddressLookupTable(table, res.Value[0])
+	if err != nil {
+		return 0, err
+	}
+	slotHashes, err := sysvar.DeserializeSlotHashes(res.Value[1].Data, res.Value[1].Owner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deserialize slot hashes, err: %w", err)
+	}
+	return state.Status(res.Context.Slot, slotHashes), nil
+}
+
+// Deactivate starts the cool down, the table can be closed once it's deactivated
+func (m *AddressLookupTableManager) Deactivate(ctx context.Context, table common.PublicKey) error {
+	return m.send(ctx, []types.Instruction{
+		address_lookup_table.DeactivateLookupTable(address_lookup_table.DeactivateLookupTableParams{
+			LookupTable: table,
+			Authority:   m.authority.Public(),
+		}),
+	})
+}
+
+// Close closes a deactivated table and sends its lamports to the recipient
+func (m *AddressLookupTableManager) Close(ctx context.Context, table, recipient common.PublicKey) error {
+	status, err := m.Status(ctx, table)
+	if err != nil {
+		return err
+	}
+	if status != address_lookup_table.LookupTableStatusDeactivated {
+		return fmt.Errorf("%w, status: %v", ErrAddressLookupTableNotDeactivated, status)
+	}
+	return m.send(ctx, []types.Instruction{
+		address_lookup_table.CloseLookupTable(address_lookup_table.CloseLookupTableParams{
+			LookupTable: table,
+			Authority:   m.authority.Public(),
+			Recipient:   recipient,
+		}),
+	})
+}
+
+func (m *AddressLookupTableManager) commitment() rpc.Commitment {
+	if m.Commitment == "" {
+		return rpc.CommitmentConfirmed
+	}
+	return m.Commitment
+}
+
+// getTable returns the table and the slot it's read at
+func (m *AddressLookupTableManager) getTable(ctx context.Context, table common.PublicKey) (address_lookup_table.AddressLookupTable, uint64, error) {
+	res, err := m.client.GetAccountInfoAndContextWithConfig(ctx, table.ToBase58(), GetAccountInfoConfig{Commitment: m.commitment()})
+	if err != nil {
+		return address_lookup_table.AddressLookupTable{}, 0, fmt.Errorf("failed to get address lookup table, err: %w", err)
+	}
+	state, err := deserializeAddressLookupTable(table, res.Value)
+	if err != nil {
+		return address_lookup_table.AddressLookupTable{}, 0, err
+	}
+	return state, res.Context.Slot, nil
+}
+
+func deserializeAddressLookupTable(table common.PublicKey, accountInfo AccountInfo) (address_lookup_table.AddressLookupTable, error) {
+	if accountInfo.Owner == (common.PublicKey{}) {
+		return address_lookup_table.AddressLookupTable{}, fmt.Errorf("address lookup table %v not found", table.ToBase58())
+	}
+	state, err := address_lookup_table.DeserializeLookupTable(accountInfo.Data, accountInfo.Owner)
+	if err != nil {
+		return address_lookup_table.AddressLookupTable{}, fmt.Errorf("failed to deserialize address lookup table %v, err: %w", table.ToBase58(), err)
+	}
+	return state, nil
+}
+
+func (m *AddressLookupTableManager) extend(table common.PublicKey, addresses []common.PublicKey) types.Instruction {
+	payer := m.payer.Public()
+	return address_lookup_table.ExtendLookupTable(address_lookup_table.ExtendLookupTableParams{
+		LookupTable: table,
+		Authority:   m.authority.Public(),
+		Payer:       &payer,
+		Addresses:   addresses,
+	})
+}
+
+// extendChunkSize returns how many of the addresses fit in one transaction
+func (m *AddressLookupTableManager) extendChunkSize(table common.PublicKey, addresses []common.PublicKey) (int, error) {
+	fits := func(n int) error {
+		_, err := types.CompileMessage(types.NewMessageParam{
+			FeePayer:        m.payer.Public(),
+			Instructions:    []types.Instruction{m.extend(table, addresses[:n])},
+			RecentBlockhash: common.PublicKey{}.ToBase58(),
+		})
+		return err
+	}
+	if err := fits(1); err != nil {
+		return 0, fmt.Errorf("failed to compile extend lookup table, err: %w", err)
+	}
+	lo, hi := 1, len(addresses)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if fits(mid) == nil {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
+
+func (m *AddressLookupTableManager) send(ctx context.Context, instructions []types.Instruction) error {
+	signers := []types.Signer{m.payer}
+	if m.authority.Public() != m.payer.Public() {
+		signers = append(signers, m.authority)
+	}
+	tx, lastValidBlockHeight, err := m.client.NewSignedTransaction(ctx, NewSignedTransactionParam{
+		FeePayer:     m.payer.Public(),
+		Instructions: instructions,
+		Signers:      signers,
+		Commitment:   m.commitment(),
+	})
+	if err != nil {
+		return err
+	}
+	cfg := m.Config
+	cfg.LastValidBlockHeight = lastValidBlockHeight
+	_, err = m.client.SendAndConfirmTransaction(ctx, tx, cfg)
+	return err
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/address_lookup_table"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupTableAccountInfo(deactivationSlot, lastExtendedSlot uint64, lastExtendedSlotStartIndex uint8, authority common.PublicKey, addresses []common.PublicKey) string {
+	data := make([]byte, 56, 56+32*len(addresses))
+	binary.LittleEndian.PutUint32(data[0:], 1)
+	binary.LittleEndian.PutUint64(data[4:], deactivationSlot)
+	binary.LittleEndian.PutUint64(data[12:], lastExtendedSlot)
+	data[20] = lastExtendedSlotStartIndex
+	data[21] = 1
+	copy(data[22:54], authority.Bytes())
+	for _, address := range addresses {
+		data = append(data, address.Bytes()...)
+	}
+	return fmt.Sprintf(`{"data":["%v","base64"],"executable":false,"lamports":1000000,"owner":"AddressLookupTab1e1111111111111111111111111","rentEpoch":0}`, base64.StdEncoding.EncodeToString(data))
+}
+
+func slotHashesAccountInfo(slots ...uint64) string {
+	data := make([]byte, 8, 8+40*len(slots))
+	binary.LittleEndian.PutUint64(data, uint64(len(slots)))
+	for _, slot := range slots {
+		data = binary.LittleEndian.AppendUint64(data, slot)
+		data = append(data, make([]byte, 32)...)
+	}
+	return fmt.Sprintf(`{"data":["%v","base64"],"executable":false,"lamports":1000000,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}`, base64.StdEncoding.EncodeToString(data))
+}
+
+func TestAddressLookupTableManager(t *testing.T) {
+	payer := types.NewAccount()
+	authority := types.NewAccount()
+	latestBlockhash := `{"context":{"slot":100},"value":{"blockhash":"DjQ4csyDJ9ZQvNNbK838ATs5UrqMq8s4Pd5i1ts22HAQ","lastValidBlockHeight":200}}`
+	confirmed := `{"slot":100,"confirmations":1,"confirmationStatus":"confirmed","err":null}`
+	addresses := make([]common.PublicKey, 0, 70)
+	for i := 0; i < 70; i++ {
+		addresses = append(addresses, types.NewAccount().PublicKey)
+	}
+	table, bump := address_lookup_table.DeriveLookupTableAddress(authority.PublicKey, 90)
+	newManager := func(s *confirmServer) *AddressLookupTableManager {
+		m := NewAddressLookupTableManager(NewClient(s.URL), payer, authority)
+		m.Config.PollInterval = time.Millisecond
+		m.PollInterval = time.Millisecond
+		return m
+	}
+	sentInstructions := func(t *testing.T, s *confirmServer) [][]types.Instruction {
+		var output [][]types.Instruction
+		for _, rawTx := range s.sentTxs() {
+			tx, err := types.TransactionFromBase64(rawTx)
+			require.NoError(t, err)
+			require.Empty(t, tx.MissingSigners())
+			require.NoError(t, tx.VerifySignatures())
+			output = append(output, tx.Message.DecompileInstructions())
+		}
+		return output
+	}
+
+	t.Run("create", func(t *testing.T) {
+		s := newConfirmServer(t, []string{confirmed}, 150)
+		s.results = map[string][]string{
+			"getSlot":            {"90"},
+			"getLatestBlockhash": {latestBlockhash},
+		}
+		got, err := newManager(s).Create(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, table, got)
+		assert.Equal(t, [][]types.Instruction{{
+			address_lookup_table.CreateLookupTable(address_lookup_table.CreateLookupTableParams{
+				LookupTable: table,
+				Authority:   authority.PublicKey,
+				Payer:       payer.PublicKey,
+				RecentSlot:  90,
+				BumpSeed:    bump,
+			}),
+		}}, sentInstructions(t, s))
+	})
+
+	t.Run("extend in chunks", func(t *testing.T) {
+		s := newConfirmServer(t, []string{confirmed}, 150)
+		s.results = map[string][]string{
+			"getAccountInfo":     {`{"context":{"slot":100},"value":` + lookupTableAccountInfo(address_lookup_table.LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT, 95, 0, authority.PublicKey, addresses[:5]) + `}`},
+			"getLatestBlockhash": {latestBlockhash},
+		}
+		require.NoError(t, newManager(s).Extend(context.Background(), table, addresses))
+
+		var extended []common.PublicKey
+		sent := sentInstructions(t, s)
+		require.Len(t, sent, 3)
+		for _, instructions := range sent {
+			require.Len(t, instructions, 1)
+			// instruction (4) + vec len (8) + addresses
+			data := instructions[0].Data
+			n := binary.LittleEndian.Uint64(data[4:12])
+			for i := uint64(0); i < n; i++ {
+				extended = append(extended, common.PublicKeyFromBytes(data[12+32*i:44+32*i]))
+			}
+		}
+		// addresses which are in the table are skipped
+		assert.Equal(t, addresses[5:], extended)
+		for _, rawTx := range s.sentTxs()[:2] {
+			b, err := base64.StdEncoding.DecodeString(rawTx)
+			require.NoError(t, err)
+			// a chunk is as large as it can be
+			assert.Greater(t, len(b)+32, types.PacketDataSize)
+		}
+	})
+
+	t.Run("extend a full table", func(t *testing.T) {
+		full := make([]common.PublicKey, 250)
+		for i := range full {
+			full[i] = types.NewAccount().PublicKey
+		}
+		s := newConfirmServer(t, []string{confirmed}, 150)
+		s.results = map[string][]string{
+			"getAccountInfo": {`{"context":{"slot":100},"value":` + lookupTableAccountInfo(address_lookup_table.LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT, 95, 0, authority.PublicKey, full) + `}`},
+		}
+		err := newManager(s).Extend(context.Background(), table, addresses)
+		assert.ErrorIs(t, err, ErrAddressLookupTableFull)
+	})
+
+	t.Run("wait until active", func(t *testing.T) {
+		s := newConfirmServer(t, nil, 0)
+		s.results = map[string][]string{
+			"getAccountInfo": {
+				`{"context":{"slot":100},"value":` + lookupTableAccountInfo(address_lookup_table.LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT, 100, 2, authority.PublicKey, addresses[:5]) + `}`,
+				`{"context":{"slot":100},"value":` + lookupTableAccountInfo(address_lookup_table.LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT, 100, 2, authority.PublicKey, addresses[:5]) + `}`,
+				`{"context":{"slot":101},"value":` + lookupTableAccountInfo(address_lookup_table.LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT, 100, 2, authority.PublicKey, addresses[:5]) + `}`,
+			},
+		}
+		require.NoError(t, newManager(s).WaitUntilActive(context.Background(), table))
+		assert.Len(t, s.results["getAccountInfo"], 1)
+	})
+
+	t.Run("status and close", func(t *testing.T) {
+		statusOf := func(deactivationSlot uint64) string {
+			return `{"context":{"slot":106},"value":[` +
+				lookupTableAccountInfo(deactivationSlot, 95, 0, authority.PublicKey, addresses[:5]) + `,` +
+				slotHashesAccountInfo(105, 104, 102) + `]}`
+		}
+
+		s := newConfirmServer(t, nil, 0)
+		s.results = map[string][]string{
+			"getMultipleAccounts": {
+				statusOf(address_lookup_table.LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT),
+				statusOf(104),
+			},
+		}
+		m := newManager(s)
+		status, err := m.Status(context.Background(), table)
+		require.NoError(t, err)
+		assert.Equal(t, address_lookup_table.LookupTableStatusActivated, status)
+
+		err = m.Close(context.Background(), table, payer.PublicKey)
+		assert.ErrorIs(t, err, ErrAddressLookupTableNotDeactivated)
+		assert.EqualError(t, err, "address lookup table is not deactivated, status: deactivating")
+		assert.Empty(t, s.sentTxs())
+
+		s = newConfirmServer(t, []string{confirmed}, 150)
+		s.results = map[string][]string{
+			"getMultipleAccounts": {statusOf(100)},
+			"getLatestBlockhash":  {latestBlockhash},
+		}
+		recipient := types.NewAccount().PublicKey
+		require.NoError(t, newManager(s).Close(context.Background(), table, recipient))
+		assert.Equal(t, [][]types.Instruction{{
+			address_lookup_table.CloseLookupTable(address_lookup_table.CloseLookupTableParams{
+				LookupTable: table,
+				Authority:   authority.PublicKey,
+				Recipient:   recipient,
+			}),
+		}}, sentInstructions(t, s))
+	})
+}
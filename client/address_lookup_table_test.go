@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+func TestClient_DecompileInstructions(t *testing.T) {
+	message := types.Message{
+		Version: types.MessageVersionV0,
+		Header: types.MessageHeader{
+			NumRequireSignatures:        1,
+			NumReadonlySignedAccounts:   0,
+			NumReadonlyUnsignedAccounts: 1,
+		},
+		Accounts: []common.PublicKey{
+			common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz"),
+			common.SystemProgramID,
+		},
+		RecentBlockHash: "9rAtxuhtKn8qagc3UtZFyhLrw5zkh6etv43TibaXuSKo",
+		Instructions: []types.CompiledInstruction{
+			{
+				ProgramIDIndex: 1,
+				Accounts:       []int{0, 2},
+				Data:           []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		AddressLookupTables: []types.CompiledAddressLookupTable{
+			{
+				AccountKey:      common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+				WritableIndexes: []uint8{1},
+				ReadonlyIndexes: []uint8{},
+			},
+		},
+	}
+
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"], {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.14.10","slot":187635130},"value":[{"data":["AQAAAP//////////5ms9CQAAAAAAAdcUkx66ahmo9NxsAZr/Jk9fv2jFoo7gs7mHVc451knTAAB/YGv6mIXQ4En7cZeAi1ZQZUaKMo2Z2m44J3q1eDdWuR0LcQRl7yenyXCc7+wk+4xMx5bk2tYUe7S1Z6BH++17","base64"],"executable":false,"lamports":1113600,"owner":"AddressLookupTab1e1111111111111111111111111","rentEpoch":0}]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.DecompileInstructions(context.Background(), message)
+				},
+				ExpectedValue: []types.Instruction{
+					{
+						ProgramID: common.SystemProgramID,
+						Accounts: []types.AccountMeta{
+							{PubKey: common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz"), IsSigner: true, IsWritable: true},
+							{PubKey: common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i"), IsSigner: false, IsWritable: true},
+						},
+						Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+					},
+				},
+				ExpectedError: nil,
+			},
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMultipleAccounts", "params":[["HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"], {"encoding": "base64"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.14.10","slot":187635130},"value":[null]},"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.DecompileInstructions(context.Background(), message)
+				},
+				ExpectedValue: ([]types.Instruction)(nil),
+				ExpectedError: errors.New("failed to get address lookup tables, err: address lookup table HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY not found"),
+			},
+		},
+	)
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+// Batch collects typed calls and sends them in a single json rpc batch request
+type Batch struct {
+	client    *Client
+	calls     []rpc.BatchRequest
+	resolvers []func()
+}
+
+// BatchResult is filled after Batch.Send returns
+type BatchResult[T any] struct {
+	Value T
+	Err   error
+}
+
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Send sends all collected calls. the returned error is only for the whole
+// batch, the error of each call is in its own BatchResult.
+func (b *Batch) Send(ctx context.Context) error {
+	err := b.client.RpcClient.CallBatch(ctx, b.calls...)
+	if err != nil {
+		return err
+	}
+	for _, resolve := range b.resolvers {
+		resolve()
+	}
+	return nil
+}
+
+func addBatchCall[A any, B any](b *Batch, call *rpc.BatchCall[A], convert func(A) (B, error)) *BatchResult[B] {
+	result := &BatchResult[B]{}
+	b.calls = append(b.calls, call)
+	b.resolvers = append(b.resolvers, func() {
+		result.Value, result.Err = process(
+			func() (rpc.JsonRpcResponse[A], error) {
+				return call.Response, call.Err
+			},
+			convert,
+		)
+	})
+	return result
+}
+
+// GetBalance adds a `getBalance` call into the batch
+func (b *Batch) GetBalance(base58Addr string) *BatchResult[uint64] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[uint64]]("getBalance", base58Addr), value[uint64])
+}
+
+// GetBalanceWithConfig adds a `getBalance` call into the batch
+func (b *Batch) GetBalanceWithConfig(base58Addr string, cfg GetBalanceConfig) *BatchResult[uint64] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[uint64]]("getBalance", base58Addr, cfg.toRpc()), value[uint64])
+}
+
+// GetAccountInfo adds a `getAccountInfo` call into the batch
+func (b *Batch) GetAccountInfo(base58Addr string) *BatchResult[AccountInfo] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[rpc.AccountInfo]]("getAccountInfo", base58Addr, GetAccountInfoConfig{}.toRpc()), convertGetAccountInfo)
+}
+
+// GetAccountInfoWithConfig adds a `getAccountInfo` call into the batch
+func (b *Batch) GetAccountInfoWithConfig(base58Addr string, cfg GetAccountInfoConfig) *BatchResult[AccountInfo] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[rpc.AccountInfo]]("getAccountInfo", base58Addr, cfg.toRpc()), convertGetAccountInfo)
+}
+
+// GetMultipleAccounts adds a `getMultipleAccounts` call into the batch
+func (b *Batch) GetMultipleAccounts(addrs []string) *BatchResult[[]AccountInfo] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[[]rpc.AccountInfo]]("getMultipleAccounts", addrs, GetMultipleAccountsConfig{}.toRpc()), convertGetMultipleAccounts)
+}
+
+// GetTokenAccountBalance adds a `getTokenAccountBalance` call into the batch
+func (b *Batch) GetTokenAccountBalance(base58Addr string) *BatchResult[TokenAmount] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[rpc.TokenAccountBalance]]("getTokenAccountBalance", base58Addr), convertGetTokenAccountBalance)
+}
+
+// GetSignatureStatus adds a `getSignatureStatuses` call with a single signature into the batch
+func (b *Batch) GetSignatureStatus(signature string) *BatchResult[*rpc.SignatureStatus] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[rpc.SignatureStatuses]]("getSignatureStatuses", []string{signature}), convertGetSignatureStatus)
+}
+
+// GetSignatureStatuses adds a `getSignatureStatuses` call into the batch
+func (b *Batch) GetSignatureStatuses(signatures []string) *BatchResult[rpc.SignatureStatuses] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[rpc.SignatureStatuses]]("getSignatureStatuses", signatures), value[rpc.SignatureStatuses])
+}
+
+// GetSignatureStatusesWithConfig adds a `getSignatureStatuses` call into the batch
+func (b *Batch) GetSignatureStatusesWithConfig(signatures []string, cfg GetSignatureStatusesConfig) *BatchResult[rpc.SignatureStatuses] {
+	return addBatchCall(b, rpc.NewBatchCall[rpc.ValueWithContext[rpc.SignatureStatuses]]("getSignatureStatuses", signatures, cfg.toRpc()), value[rpc.SignatureStatuses])
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/blocto/solana-go-sdk/pkg/pointer"
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+func TestClient_Batch(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getBalance","params":["CvRuXXptXE6itGCvMxPWDnc2UYfSGKszWS14wvsK8CzK"]},{"jsonrpc":"2.0","id":2,"method":"getAccountInfo","params":["F5RYi7FMPefkc7okJNh21Hcsch7RUaLVr8Rzc8SQqxUb",{"encoding":"base64"}]},{"jsonrpc":"2.0","id":3,"method":"getSignatureStatuses","params":[["3yAwJ3Ttfmbf1fDuoxtDU9Ahk8dLDAdzX7u3wVV6s6KKdiVSHhfWr5egWXvUp2ZbLJzE2Q3y8W2pLW5vWPNwQYUX"]]},{"jsonrpc":"2.0","id":4,"method":"getBalance","params":["abc"]}]`,
+				ResponseBody: `[{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":4},{"jsonrpc":"2.0","result":{"context":{"slot":86928},"value":[{"confirmationStatus":"finalized","confirmations":null,"err":null,"slot":86879,"status":{"Ok":null}}]},"id":3},{"jsonrpc":"2.0","result":{"context":{"slot":187552526},"value":2039280},"id":1},{"jsonrpc":"2.0","result":{"context":{"slot":77317716},"value":{"data":["AQID","base64"],"executable":false,"lamports":21474700400,"owner":"11111111111111111111111111111111","rentEpoch":178}},"id":2}]`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					b := c.NewBatch()
+					balance := b.GetBalance("CvRuXXptXE6itGCvMxPWDnc2UYfSGKszWS14wvsK8CzK")
+					accountInfo := b.GetAccountInfo("F5RYi7FMPefkc7okJNh21Hcsch7RUaLVr8Rzc8SQqxUb")
+					signatureStatus := b.GetSignatureStatus("3yAwJ3Ttfmbf1fDuoxtDU9Ahk8dLDAdzX7u3wVV6s6KKdiVSHhfWr5egWXvUp2ZbLJzE2Q3y8W2pLW5vWPNwQYUX")
+					invalid := b.GetBalance("abc")
+					err := b.Send(context.Background())
+					return []any{*balance, *accountInfo, *signatureStatus, *invalid}, err
+				},
+				ExpectedValue: []any{
+					BatchResult[uint64]{
+						Value: 2039280,
+					},
+					BatchResult[AccountInfo]{
+						Value: AccountInfo{
+							Lamports:  21474700400,
+							Owner:     common.SystemProgramID,
+							RentEpoch: 178,
+							Data:      []byte{1, 2, 3},
+						},
+					},
+					BatchResult[*rpc.SignatureStatus]{
+						Value: &rpc.SignatureStatus{
+							Slot:               86879,
+							ConfirmationStatus: pointer.Get[rpc.Commitment](rpc.CommitmentFinalized),
+						},
+					},
+					BatchResult[uint64]{
+						Err: &rpc.JsonRpcError{Code: -32602, Message: "Invalid param: Invalid"},
+					},
+				},
+				ExpectedError: nil,
+			},
+			{
+				Name:         "empty signature status",
+				RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getSignatureStatuses","params":[["3yAwJ3Ttfmbf1fDuoxtDU9Ahk8dLDAdzX7u3wVV6s6KKdiVSHhfWr5egWXvUp2ZbLJzE2Q3y8W2pLW5vWPNwQYUX"]]}]`,
+				ResponseBody: `[{"jsonrpc":"2.0","result":{"context":{"slot":86928},"value":[]},"id":1}]`,
+				F: func(url string) (any, error) {
+					b := NewClient(url).NewBatch()
+					signatureStatus := b.GetSignatureStatus("3yAwJ3Ttfmbf1fDuoxtDU9Ahk8dLDAdzX7u3wVV6s6KKdiVSHhfWr5egWXvUp2ZbLJzE2Q3y8W2pLW5vWPNwQYUX")
+					err := b.Send(context.Background())
+					return *signatureStatus, err
+				},
+				ExpectedValue: BatchResult[*rpc.SignatureStatus]{
+					Err: fmt.Errorf("expected 1 signature status, got: 0"),
+				},
+				ExpectedError: nil,
+			},
+		},
+	)
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/compute_budget"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+// MaxComputeUnitLimit is the max compute units a transaction can request
+const MaxComputeUnitLimit uint32 = 1_400_000
+
+// maxPrioritizationFeeAddresses is the max number of accounts getRecentPrioritizationFees accepts
+const maxPrioritizationFeeAddresses = 128
+
+type PrepareComputeBudgetParam struct {
+	FeePayer     common.PublicKey
+	Instructions []types.Instruction
+	// RecentBlockhash is used by the simulation only, the node replaces it anyway
+	RecentBlockhash            string
+	AddressLookupTableAccounts []types.AddressLookupTableAccount
+
+	// UnitMargin is the fraction added on top of the simulated units, e.g. 0.1 for 10%
+	UnitMargin float64
+	// Units skips the simulation if it is set
+	Units uint32
+
+	// Percentile of recent prioritization fees of writable accounts, 1~100, default: 50
+	Percentile uint8
+	// MaxMicroLamports caps the compute unit price, zero means no cap
+	MaxMicroLamports uint64
+	// MicroLamports skips the fee lookup if it is set
+	MicroLamports uint64
+}
+
+type ComputeBudget struct {
+	Units         uint32
+	MicroLamports uint64
+	// Instructions starts with SetComputeUnitLimit and SetComputeUnitPrice, followed
+	// by the given instructions without their own ones
+	Instructions []types.Instruction
+}
+
+// PrepareComputeBudget simulates the instructions to measure compute units, prices
+// compute units by recent prioritization fees, then injects compute budget
+// instructions. existing SetComputeUnitLimit and SetComputeUnitPrice are replaced.
+func (c *Client) PrepareComputeBudget(ctx context.Context, param PrepareComputeBudgetParam) (ComputeBudget, error) {
+	instructions := removeComputeBudgetInstructions(param.Instructions)
+
+	microLamports := param.MicroLamports
+	if microLamports == 0 {
+		fees, err := c.GetRecentPrioritizationFees(ctx, writableAccounts(param.FeePayer, instructions))
+		if err != nil {
+			return ComputeBudget{}, fmt.Errorf("failed to get recent prioritization fees, err: %w", err)
+		}
+		values := make([]uint64, 0, len(fees))
+		for _, fee := range fees {
+			values = append(values, fee.PrioritizationFee)
+		}
+		microLamports = percentile(values, param.Percentile)
+	}
+	if param.MaxMicroLamports > 0 && microLamports > param.MaxMicroLamports {
+		microLamports = param.MaxMicroLamports
+	}
+
+	units := param.Units
+	if units == 0 {
+		consumed, err := c.simulateComputeUnits(ctx, param, withComputeBudget(instructions, MaxComputeUnitLimit, microLamports))
+		if err != nil {
+			return ComputeBudget{}, err
+		}
+		margin := math.Ceil(float64(consumed) * (1 + param.UnitMargin))
+		units = uint32(math.Min(margin, float64(MaxComputeUnitLimit)))
+	}
+
+	return ComputeBudget{
+		Units:         units,
+		MicroLamports: microLamports,
+		Instructions:  withComputeBudget(instructions, units, microLamports),
+	}, nil
+}
+
+func (c *Client) simulateComputeUnits(ctx context.Context, param PrepareComputeBudgetParam, instructions []types.Instruction) (uint64, error) {
+	recentBlockhash := param.RecentBlockhash
+	if recentBlockhash == "" {
+		recentBlockhash = common.PublicKey{}.ToBase58()
+	}
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:                   param.FeePayer,
+		Instructions:               instructions,
+		RecentBlockhash:            recentBlockhash,
+		AddressLookupTableAccounts: param.AddressLookupTableAccounts,
+	})
+	signatures := make([]types.Signature, 0, message.Header.NumRequireSignatures)
+	for i := uint8(0); i < message.Header.NumRequireSignatures; i++ {
+		signatures = append(signatures, make([]byte, 64))
+	}
+
+	res, err := c.SimulateTransactionWithConfig(
+		ctx,
+		types.Transaction{Signatures: signatures, Message: message},
+		SimulateTransactionConfig{ReplaceRecentBlockhash: true},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate transaction, err: %w", err)
+	}
+	if res.Err != nil {
+		txErr, err := res.TransactionError()
+		if err != nil {
+			return 0, fmt.Errorf("simulation failed, err: %v", res.Err)
+		}
+		DefaultProgramErrorCatalog.Resolve(txErr, message)
+		return 0, fmt.Errorf("simulation failed, err: %w", txErr)
+	}
+	if res.UnitConsumed == nil {
+		return 0, errors.New("simulation didn't return units consumed")
+	}
+	return *res.UnitConsumed, nil
+}
+
+func withComputeBudget(instructions []types.Instruction, units uint32, microLamports uint64) []types.Instruction {
+	output := make([]types.Instruction, 0, len(instructions)+2)
+	output = append(output,
+		compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: units}),
+		compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: microLamports}),
+	)
+	return append(output, instructions...)
+}
+
+func removeComputeBudgetInstructions(instructions []types.Instruction) []types.Instruction {
+	output := make([]types.Instruction, 0, len(instructions))
+	for _, instruction := range instructions {
+		if instruction.ProgramID == common.ComputeBudgetProgramID && len(instruction.Data) > 0 {
+			switch compute_budget.Instruction(instruction.Data[0]) {
+			case compute_budget.InstructionSetComputeUnitLimit, compute_budget.InstructionSetComputeUnitPrice:
+				continue
+			}
+		}
+		output = append(output, instruction)
+	}
+	return output
+}
+
+func writableAccounts(feePayer common.PublicKey, instructions []types.Instruction) []common.PublicKey {
+	seen := map[common.PublicKey]struct{}{feePayer: {}}
+	output := []common.PublicKey{feePayer}
+	for _, instruction := range instructions {
+		for _, account := range instruction.Accounts {
+			if _, ok := seen[account.PubKey]; ok || !account.IsWritable {
+				continue
+			}
+			seen[account.PubKey] = struct{}{}
+			output = append(output, account.PubKey)
+		}
+	}
+	if len(output) > maxPrioritizationFeeAddresses {
+		output = output[:maxPrioritizationFeeAddresses]
+	}
+	return output
+}
+
+// percentile uses the nearest rank method
+func percentile(values []uint64, p uint8) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	if p == 0 {
+		p = 50
+	}
+	if p > 100 {
+		p = 100
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(values))))
+	if rank < 1 {
+		rank = 1
+	}
+	return values[rank-1]
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/compute_budget"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PrepareComputeBudget(t *testing.T) {
+	feePayer := common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	transfer := system.Transfer(system.TransferParam{From: feePayer, To: to, Amount: 1})
+	heapFrame := compute_budget.RequestHeapFrame(compute_budget.RequestHeapFrameParam{Bytes: 64 * 1024})
+
+	var simulatedTx types.Transaction
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		switch body.Method {
+		case "getRecentPrioritizationFees":
+			assert.JSONEq(t, `["FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz","A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"]`, string(body.Params[0]))
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":[{"prioritizationFee":300,"slot":1},{"prioritizationFee":0,"slot":2},{"prioritizationFee":1000,"slot":3},{"prioritizationFee":100,"slot":4},{"prioritizationFee":200,"slot":5}],"id":1}`))
+		case "simulateTransaction":
+			assert.JSONEq(t, `{"encoding":"base64","replaceRecentBlockhash":true}`, string(body.Params[1]))
+			var rawTx string
+			require.NoError(t, json.Unmarshal(body.Params[0], &rawTx))
+			b, err := base64.StdEncoding.DecodeString(rawTx)
+			require.NoError(t, err)
+			simulatedTx, err = types.TransactionDeserialize(b)
+			require.NoError(t, err)
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"accounts":null,"err":null,"logs":[],"returnData":null,"unitsConsumed":1000}},"id":1}`))
+		default:
+			t.Errorf("unexpected method %v", body.Method)
+		}
+	}))
+	defer server.Close()
+
+	got, err := NewClient(server.URL).PrepareComputeBudget(context.Background(), PrepareComputeBudgetParam{
+		FeePayer: feePayer,
+		Instructions: []types.Instruction{
+			compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: 1}),
+			heapFrame,
+			transfer,
+		},
+		UnitMargin:       0.1,
+		Percentile:       75,
+		MaxMicroLamports: 250,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ComputeBudget{
+		Units:         1100,
+		MicroLamports: 250,
+		Instructions: []types.Instruction{
+			compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: 1100}),
+			compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: 250}),
+			heapFrame,
+			transfer,
+		},
+	}, got)
+
+	// the simulation requests the max limit so it doesn't run out of units
+	simulatedInstructions := simulatedTx.Message.DecompileInstructions()
+	require.Len(t, simulatedInstructions, 4)
+	assert.Equal(t, compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: MaxComputeUnitLimit}).Data, simulatedInstructions[0].Data)
+
+	// fixed units and price don't call the node
+	got, err = NewClient("").PrepareComputeBudget(context.Background(), PrepareComputeBudgetParam{
+		FeePayer:      feePayer,
+		Instructions:  []types.Instruction{transfer},
+		Units:         500,
+		MicroLamports: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(500), got.Units)
+	assert.Equal(t, uint64(10), got.MicroLamports)
+	assert.Len(t, got.Instructions, 3)
+}
+
+func Test_percentile(t *testing.T) {
+	values := []uint64{50, 10, 40, 20, 30}
+	for _, tt := range []struct {
+		p    uint8
+		want uint64
+	}{
+		{p: 0, want: 30},
+		{p: 1, want: 10},
+		{p: 20, want: 10},
+		{p: 21, want: 20},
+		{p: 50, want: 30},
+		{p: 90, want: 50},
+		{p: 100, want: 50},
+		{p: 200, want: 50},
+	} {
+		assert.Equal(t, tt.want, percentile(values, tt.p), tt.p)
+	}
+	assert.Equal(t, uint64(0), percentile(nil, 50))
+}
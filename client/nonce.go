@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+var (
+	ErrNonceAccountUninitialized = errors.New("nonce account is not initialized")
+	ErrNonceAuthorityMismatch    = errors.New("nonce authority mismatch")
+	// ErrNonceAdvanced means the nonce has advanced by another transaction so the
+	// transaction which used the old nonce can't land anymore
+	ErrNonceAdvanced = errors.New("nonce advanced without the transaction")
+	// ErrNotNonceTransaction means the first instruction isn't AdvanceNonceAccount
+	ErrNotNonceTransaction = errors.New("the first instruction is not advance nonce account")
+)
+
+type NewNonceMessageParam struct {
+	FeePayer       common.PublicKey
+	NonceAccount   common.PublicKey
+	NonceAuthority common.PublicKey
+	Instructions   []types.Instruction
+	// v0 transaction
+	AddressLookupTableAccounts []types.AddressLookupTableAccount
+}
+
+// NewNonceMessage fetches the nonce account, checks it is initialized and its
+// authority, then builds a message which uses the nonce as the recent blockhash
+// and advances the nonce in the first instruction
+func (c *Client) NewNonceMessage(ctx context.Context, param NewNonceMessageParam) (types.Message, error) {
+	nonceAccount, err := c.GetNonceAccount(ctx, param.NonceAccount.ToBase58())
+	if err != nil {
+		return types.Message{}, fmt.Errorf("failed to get nonce account, err: %w", err)
+	}
+	if nonceAccount.State != system.NonceAccountStateInitialized {
+		return types.Message{}, ErrNonceAccountUninitialized
+	}
+	if nonceAccount.AuthorizedPubkey != param.NonceAuthority {
+		return types.Message{}, fmt.Errorf("%w, expected: %v, got: %v", ErrNonceAuthorityMismatch, nonceAccount.AuthorizedPubkey.ToBase58(), param.NonceAuthority.ToBase58())
+	}
+
+	instructions := make([]types.Instruction, 0, len(param.Instructions)+1)
+	instructions = append(instructions, system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{
+		Nonce: param.NonceAccount,
+		Auth:  param.NonceAuthority,
+	}))
+	for _, instruction := range param.Instructions {
+		// the advance of this nonce has been put first, advances of other nonce
+		// accounts are ordinary instructions
+		if nonceAccountPubkey, ok := advancedNonceAccount(instruction); ok && nonceAccountPubkey == param.NonceAccount {
+			continue
+		}
+		instructions = append(instructions, instruction)
+	}
+
+	return types.NewMessage(types.NewMessageParam{
+		FeePayer:                   param.FeePayer,
+		Instructions:               instructions,
+		RecentBlockhash:            nonceAccount.Nonce.ToBase58(),
+		AddressLookupTableAccounts: param.AddressLookupTableAccounts,
+	}), nil
+}
+
+// SendAndConfirmNonceTransaction works like SendAndConfirmTransaction but a nonce tx
+// never expires, so it gives up with ErrNonceAdvanced once the nonce moves on
+// without the tx. LastValidBlockHeight of the config is ignored.
+func (c *Client) SendAndConfirmNonceTransaction(ctx context.Context, tx types.Transaction, cfg SendAndConfirmTransactionConfig) (string, error) {
+	if len(tx.Message.Instructions) == 0 {
+		return "", ErrNotNonceTransaction
+	}
+	instruction, err := decompileFirstInstruction(tx.Message)
+	if err != nil {
+		return "", err
+	}
+	nonceAccountPubkey, ok := advancedNonceAccount(instruction)
+	if !ok {
+		return "", ErrNotNonceTransaction
+	}
+	usedNonce := tx.Message.RecentBlockHash
+
+	return c.sendAndConfirmTransaction(ctx, tx, cfg, func(ctx context.Context) error {
+		nonceAccount, err := c.GetNonceAccount(ctx, nonceAccountPubkey.ToBase58())
+		if err == nil && nonceAccount.Nonce.ToBase58() != usedNonce {
+			return ErrNonceAdvanced
+		}
+		return nil
+	})
+}
+
+// decompileFirstInstruction only resolves static keys, it is enough for the
+// advance nonce instruction since its accounts can't be loaded from lookup tables
+func decompileFirstInstruction(message types.Message) (types.Instruction, error) {
+	cins := message.Instructions[0]
+	if cins.ProgramIDIndex >= len(message.Accounts) {
+		return types.Instruction{}, ErrNotNonceTransaction
+	}
+	accounts := make([]types.AccountMeta, 0, len(cins.Accounts))
+	for _, idx := range cins.Accounts {
+		if idx >= len(message.Accounts) {
+			return types.Instruction{}, ErrNotNonceTransaction
+		}
+		accounts = append(accounts, types.AccountMeta{PubKey: message.Accounts[idx]})
+	}
+	return types.Instruction{
+		ProgramID: message.Accounts[cins.ProgramIDIndex],
+		Accounts:  accounts,
+		Data:      cins.Data,
+	}, nil
+}
+
+// advancedNonceAccount returns the nonce account if the instruction is AdvanceNonceAccount
+func advancedNonceAccount(instruction types.Instruction) (common.PublicKey, bool) {
+	if instruction.ProgramID != common.SystemProgramID ||
+		len(instruction.Data) != 4 ||
+		binary.LittleEndian.Uint32(instruction.Data) != uint32(system.InstructionAdvanceNonceAccount) ||
+		len(instruction.Accounts) == 0 {
+		return common.PublicKey{}, false
+	}
+	return instruction.Accounts[0].PubKey, true
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/blocto/solana-go-sdk/program/memo"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nonceAccountInfo(state uint32, authority, nonce common.PublicKey) string {
+	data := make([]byte, system.NonceAccountSize)
+	binary.LittleEndian.PutUint32(data[0:4], 1)
+	binary.LittleEndian.PutUint32(data[4:8], state)
+	copy(data[8:40], authority.Bytes())
+	copy(data[40:72], nonce.Bytes())
+	binary.LittleEndian.PutUint64(data[72:80], 5000)
+	return fmt.Sprintf(`{"data":["%v","base64"],"executable":false,"lamports":1447680,"owner":"11111111111111111111111111111111","rentEpoch":0}`, base64.StdEncoding.EncodeToString(data))
+}
+
+func TestClient_NewNonceMessage(t *testing.T) {
+	feePayer := common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz")
+	nonceAccount := common.PublicKeyFromString("DJyNpXgggw1WGgjTVzFsNjb3fuQZVMqhoakvSBfX9LYx")
+	authority := common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde")
+	nonce := common.PublicKeyFromString("9rAtxuhtKn8qagc3UtZFyhLrw5zkh6etv43TibaXuSKo")
+	advance := system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{Nonce: nonceAccount, Auth: authority})
+	otherAdvance := system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{
+		Nonce: common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i"),
+		Auth:  authority,
+	})
+	memoInstruction := memo.BuildMemo(memo.BuildMemoParam{Memo: []byte("use nonce")})
+
+	requestBody := `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["DJyNpXgggw1WGgjTVzFsNjb3fuQZVMqhoakvSBfX9LYx", {"encoding": "base64"}]}`
+	param := NewNonceMessageParam{
+		FeePayer:       feePayer,
+		NonceAccount:   nonceAccount,
+		NonceAuthority: authority,
+		// a misplaced advance is moved to the first
+		Instructions: []types.Instruction{memoInstruction, advance},
+	}
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name:         "ok",
+				RequestBody:  requestBody,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":` + nonceAccountInfo(system.NonceAccountStateInitialized, authority, nonce) + `},"id":1}`,
+				F: func(url string) (any, error) {
+					return NewClient(url).NewNonceMessage(context.Background(), param)
+				},
+				ExpectedValue: types.NewMessage(types.NewMessageParam{
+					FeePayer:        feePayer,
+					RecentBlockhash: nonce.ToBase58(),
+					Instructions:    []types.Instruction{advance, memoInstruction},
+				}),
+				ExpectedError: nil,
+			},
+			{
+				Name:         "advance other nonce account",
+				RequestBody:  requestBody,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":` + nonceAccountInfo(system.NonceAccountStateInitialized, authority, nonce) + `},"id":1}`,
+				F: func(url string) (any, error) {
+					return NewClient(url).NewNonceMessage(context.Background(), NewNonceMessageParam{
+						FeePayer:       feePayer,
+						NonceAccount:   nonceAccount,
+						NonceAuthority: authority,
+						Instructions:   []types.Instruction{otherAdvance, memoInstruction},
+					})
+				},
+				ExpectedValue: types.NewMessage(types.NewMessageParam{
+					FeePayer:        feePayer,
+					RecentBlockhash: nonce.ToBase58(),
+					Instructions:    []types.Instruction{advance, otherAdvance, memoInstruction},
+				}),
+				ExpectedError: nil,
+			},
+			{
+				Name:         "uninitialized",
+				RequestBody:  requestBody,
+				ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":` + nonceAccountInfo(system.NonceAccountStateUninitialized, common.PublicKey{}, common.PublicKey{}) + `},"id":1}`,
+				F: func(url string) (any, error) {
+					return NewClient(url).NewNonceMessage(context.Background(), param)
+				},
+				ExpectedValue: types.Message{},
+				ExpectedError: ErrNonceAccountUninitialized,
+			},
+		},
+	)
+
+	client_test.Test(t, client_test.Param{
+		Name:         "authority mismatch",
+		RequestBody:  requestBody,
+		ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":` + nonceAccountInfo(system.NonceAccountStateInitialized, feePayer, nonce) + `},"id":1}`,
+		F: func(url string) (any, error) {
+			_, err := NewClient(url).NewNonceMessage(context.Background(), param)
+			assert.True(t, errors.Is(err, ErrNonceAuthorityMismatch))
+			return nil, err
+		},
+		ExpectedValue: nil,
+		ExpectedError: fmt.Errorf("%w, expected: FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz, got: 9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde", ErrNonceAuthorityMismatch),
+	})
+}
+
+func TestClient_SendAndConfirmNonceTransaction(t *testing.T) {
+	feePayer := types.NewAccount()
+	nonceAccount := common.PublicKeyFromString("DJyNpXgggw1WGgjTVzFsNjb3fuQZVMqhoakvSBfX9LYx")
+	nonce := common.PublicKeyFromString("9rAtxuhtKn8qagc3UtZFyhLrw5zkh6etv43TibaXuSKo")
+	newNonce := common.PublicKeyFromString("5EvWPqKeYfN2P7SAQZ2TLnXhV3Ltjn6qEhK1F279dUUW")
+
+	newTx := func(instructions ...types.Instruction) types.Transaction {
+		tx, err := types.NewTransaction(types.NewTransactionParam{
+			Message: types.NewMessage(types.NewMessageParam{
+				FeePayer:        feePayer.PublicKey,
+				RecentBlockhash: nonce.ToBase58(),
+				Instructions:    instructions,
+			}),
+			Signers: []types.Account{feePayer},
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	tx := newTx(
+		system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{Nonce: nonceAccount, Auth: feePayer.PublicKey}),
+		memo.BuildMemo(memo.BuildMemoParam{Memo: []byte("use nonce")}),
+	)
+
+	t.Run("confirmed", func(t *testing.T) {
+		s := newConfirmServer(t, []string{
+			`null`,
+			`{"slot":1,"confirmations":1,"confirmationStatus":"confirmed","err":null}`,
+		}, 0)
+		s.accountInfo = nonceAccountInfo(system.NonceAccountStateInitialized, feePayer.PublicKey, nonce)
+
+		sig, err := NewClient(s.URL).SendAndConfirmNonceTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			PollInterval: time.Millisecond,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, base58.Encode(tx.Signatures[0]), sig)
+	})
+
+	t.Run("nonce advanced", func(t *testing.T) {
+		s := newConfirmServer(t, []string{`null`}, 0)
+		s.accountInfo = nonceAccountInfo(system.NonceAccountStateInitialized, feePayer.PublicKey, newNonce)
+
+		_, err := NewClient(s.URL).SendAndConfirmNonceTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			// the block height should be ignored
+			LastValidBlockHeight: 1,
+			PollInterval:         time.Millisecond,
+		})
+		assert.ErrorIs(t, err, ErrNonceAdvanced)
+	})
+
+	t.Run("nonce advanced by the processed tx", func(t *testing.T) {
+		// our own tx advances the nonce once it is processed, it isn't confirmed yet
+		s := newConfirmServer(t, []string{
+			`{"slot":1,"confirmations":0,"confirmationStatus":"processed","err":null}`,
+			`{"slot":1,"confirmations":0,"confirmationStatus":"processed","err":null}`,
+			`{"slot":1,"confirmations":0,"confirmationStatus":"processed","err":null}`,
+			`{"slot":1,"confirmations":1,"confirmationStatus":"confirmed","err":null}`,
+		}, 0)
+		s.accountInfo = nonceAccountInfo(system.NonceAccountStateInitialized, feePayer.PublicKey, newNonce)
+
+		sig, err := NewClient(s.URL).SendAndConfirmNonceTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			PollInterval: time.Millisecond,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, base58.Encode(tx.Signatures[0]), sig)
+	})
+
+	t.Run("not a nonce transaction", func(t *testing.T) {
+		_, err := NewClient("").SendAndConfirmNonceTransaction(
+			context.Background(),
+			newTx(memo.BuildMemo(memo.BuildMemoParam{Memo: []byte("use nonce")})),
+			SendAndConfirmTransactionConfig{},
+		)
+		assert.ErrorIs(t, err, ErrNotNonceTransaction)
+	})
+}
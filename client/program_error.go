@@ -0,0 +1,26 @@
+package client
+
+import (
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/blocto/solana-go-sdk/program/stake"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/program/token"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+// DefaultProgramErrorCatalog resolves custom errors of transaction errors the client
+// returns. it knows programs this sdk ships, register your own programs to it.
+var DefaultProgramErrorCatalog = NewDefaultProgramErrorCatalog()
+
+// NewDefaultProgramErrorCatalog returns a catalog which has errors of system, token,
+// token-2022, stake and metaplex token metadata programs
+func NewDefaultProgramErrorCatalog() *types.ProgramErrorCatalog {
+	c := types.NewProgramErrorCatalog()
+	c.Register(common.SystemProgramID, system.ProgramErrors)
+	c.Register(common.TokenProgramID, token.ProgramErrors)
+	c.Register(common.Token2022ProgramID, token.ProgramErrors)
+	c.Register(common.StakeProgramID, stake.ProgramErrors)
+	c.Register(common.MetaplexTokenMetaProgramID, token_metadata.ProgramErrors)
+	return c
+}
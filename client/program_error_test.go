@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/blocto/solana-go-sdk/program/token"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SimulateTransaction_ProgramError(t *testing.T) {
+	feePayer := types.NewAccount()
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			RecentBlockhash: "9rAtxuhtKn8qagc3UtZFyhLrw5zgh6vg2Ho8zqXaGHb3",
+			Instructions: []types.Instruction{
+				token.Transfer(token.TransferParam{
+					From:   common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+					To:     common.PublicKeyFromString("AyHWro8zumyZN68Mfxx1MDpRsW5mbsUSN6UVxz7VDuzm"),
+					Auth:   feePayer.PublicKey,
+					Amount: 1,
+				}),
+			},
+		}),
+		Signers: []types.Account{feePayer},
+	})
+	require.NoError(t, err)
+	rawTx, err := tx.Serialize()
+	require.NoError(t, err)
+
+	client_test.Test(t, client_test.Param{
+		RequestBody:  fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"simulateTransaction","params":["%v", {"encoding": "base64"}]}`, base64.StdEncoding.EncodeToString(rawTx)),
+		ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.14.5","slot":159776096},"value":{"accounts":null,"err":{"InstructionError":[0,{"Custom":1}]},"logs":["Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [1]","Program log: Error: insufficient funds","Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1"],"returnData":null,"unitsConsumed":0}},"id":1}`,
+		F: func(url string) (any, error) {
+			c := NewClient(url)
+			res, err := c.SimulateTransaction(context.Background(), tx)
+			if err != nil {
+				return nil, err
+			}
+			txErr, err := res.TransactionError()
+			if err != nil {
+				return nil, err
+			}
+			DefaultProgramErrorCatalog.Resolve(txErr, tx.Message)
+			return txErr.Error(), nil
+		},
+		ExpectedValue: "Error processing Instruction 0: token: insufficient funds",
+		ExpectedError: nil,
+	})
+}
+
+func TestDefaultProgramErrorCatalog(t *testing.T) {
+	for _, tt := range []struct {
+		programId common.PublicKey
+		code      uint32
+		want      string
+	}{
+		{common.SystemProgramID, 1, "system: account does not have enough SOL to perform the operation"},
+		{common.TokenProgramID, 1, "token: insufficient funds"},
+		{common.Token2022ProgramID, 17, "token: account is frozen"},
+		{common.StakeProgramID, 1, "stake: lockup has not yet expired"},
+		{common.MetaplexTokenMetaProgramID, 7, "token metadata: update authority given does not match"},
+	} {
+		e, ok := DefaultProgramErrorCatalog.Lookup(tt.programId, tt.code)
+		require.True(t, ok, tt.want)
+		assert.Equal(t, tt.want, e.Error())
+	}
+
+	_, ok := DefaultProgramErrorCatalog.Lookup(common.MemoProgramID, 1)
+	assert.False(t, ok)
+	// lookup table errors are transaction errors of address loading, not custom codes
+	_, ok = DefaultProgramErrorCatalog.Lookup(common.AddressLookupTableProgramID, 3)
+	assert.False(t, ok)
+
+	txErr := &types.TransactionError{
+		Type:             types.TransactionErrorInstructionError,
+		InstructionError: &types.InstructionError{Type: types.InstructionErrorCustom, Custom: 1},
+	}
+	DefaultProgramErrorCatalog.Resolve(txErr, types.Message{
+		Accounts:     []common.PublicKey{common.TokenProgramID},
+		Instructions: []types.CompiledInstruction{{ProgramIDIndex: 0}},
+	})
+	var programErr *types.ProgramError
+	require.True(t, errors.As(txErr, &programErr))
+	assert.Equal(t, "InsufficientFunds", programErr.Name)
+}
+
+func TestTransaction_TransactionError(t *testing.T) {
+	tx := Transaction{
+		Meta: &TransactionMeta{
+			Err: map[string]any{"InstructionError": []any{float64(0), map[string]any{"Custom": float64(1)}}},
+		},
+		Transaction: types.Transaction{
+			Message: types.Message{
+				Accounts:     []common.PublicKey{common.TokenProgramID},
+				Instructions: []types.CompiledInstruction{{ProgramIDIndex: 0}},
+			},
+		},
+	}
+	txErr, err := tx.TransactionError()
+	require.NoError(t, err)
+	assert.Equal(t, "Error processing Instruction 0: token: insufficient funds", txErr.Error())
+
+	txErr, err = Transaction{Meta: &TransactionMeta{}}.TransactionError()
+	assert.NoError(t, err)
+	assert.Nil(t, txErr)
+
+	txErr, err = Transaction{}.TransactionError()
+	assert.NoError(t, err)
+	assert.Nil(t, txErr)
+}
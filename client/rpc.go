@@ -35,6 +35,11 @@ type TokenAmount struct {
 	UIAmountString string
 }
 
+// DecimalAmount returns the exact amount with decimals instead of UIAmountString
+func (t TokenAmount) DecimalAmount() common.Amount {
+	return common.NewAmount(t.Amount, t.Decimals)
+}
+
 func newTokenAmount(amount string, decimals uint8, uiAmountString string) (TokenAmount, error) {
 	u64Amount, err := strconv.ParseUint(amount, 10, 64)
 	if err != nil {
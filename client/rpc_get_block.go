@@ -47,6 +47,17 @@ type BlockTransaction struct {
 	AccountKeys []common.PublicKey
 }
 
+// DecompileInstructions decompiles the message with addresses loaded in the meta
+func (t BlockTransaction) DecompileInstructions() ([]types.Instruction, error) {
+	return decompileInstructions(t.Transaction.Message, t.Meta)
+}
+
+// TransactionError parses Meta.Err and names custom errors of known programs by
+// DefaultProgramErrorCatalog. it returns nil if the transaction succeeded.
+func (t BlockTransaction) TransactionError() (*types.TransactionError, error) {
+	return resolveTransactionError(t.Meta, t.Transaction.Message)
+}
+
 func (c *Client) GetBlock(ctx context.Context, slot uint64) (*Block, error) {
 	return process(
 		func() (rpc.JsonRpcResponse[*rpc.GetBlock], error) {
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+func TestClient_GetBlockHeight(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getBlockHeight"}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":171006457,"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetBlockHeight(
+						context.Background(),
+					)
+				},
+				ExpectedValue: uint64(171006457),
+				ExpectedError: nil,
+			},
+		},
+	)
+}
+
+func TestClient_GetBlockHeightWithConfig(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getBlockHeight", "params":[{"commitment": "confirmed"}]}`,
+				ResponseBody: `{"jsonrpc":"2.0","result":171006457,"id":1}`,
+				F: func(url string) (any, error) {
+					c := NewClient(url)
+					return c.GetBlockHeightWithConfig(
+						context.Background(),
+						GetBlockHeightConfig{
+							Commitment: rpc.CommitmentConfirmed,
+						},
+					)
+				},
+				ExpectedValue: uint64(171006457),
+				ExpectedError: nil,
+			},
+		},
+	)
+}
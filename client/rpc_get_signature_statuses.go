@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/blocto/solana-go-sdk/rpc"
 )
@@ -21,9 +22,7 @@ func (c *Client) GetSignatureStatus(ctx context.Context, signature string) (*rpc
 		func() (rpc.JsonRpcResponse[rpc.ValueWithContext[rpc.SignatureStatuses]], error) {
 			return c.RpcClient.GetSignatureStatuses(ctx, []string{signature})
 		},
-		func(v rpc.ValueWithContext[rpc.SignatureStatuses]) (*rpc.SignatureStatus, error) {
-			return v.Value[0], nil
-		},
+		convertGetSignatureStatus,
 	)
 }
 
@@ -32,9 +31,7 @@ func (c *Client) GetSignatureStatusWithConfig(ctx context.Context, signature str
 		func() (rpc.JsonRpcResponse[rpc.ValueWithContext[rpc.SignatureStatuses]], error) {
 			return c.RpcClient.GetSignatureStatusesWithConfig(ctx, []string{signature}, cfg.toRpc())
 		},
-		func(v rpc.ValueWithContext[rpc.SignatureStatuses]) (*rpc.SignatureStatus, error) {
-			return v.Value[0], nil
-		},
+		convertGetSignatureStatus,
 	)
 }
 
@@ -55,3 +52,12 @@ func (c *Client) GetSignatureStatusesWithConfig(ctx context.Context, signatures
 		value[rpc.SignatureStatuses],
 	)
 }
+
+// convertGetSignatureStatus takes the status of a single signature query, a node
+// shouldn't return other than one status for it
+func convertGetSignatureStatus(v rpc.ValueWithContext[rpc.SignatureStatuses]) (*rpc.SignatureStatus, error) {
+	if len(v.Value) != 1 {
+		return nil, fmt.Errorf("expected 1 signature status, got: %v", len(v.Value))
+	}
+	return v.Value[0], nil
+}
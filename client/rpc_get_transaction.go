@@ -39,6 +39,17 @@ func (t Transaction) Version() types.MessageVersion {
 	return t.Transaction.Message.Version
 }
 
+// DecompileInstructions decompiles the message with addresses loaded in the meta
+func (t Transaction) DecompileInstructions() ([]types.Instruction, error) {
+	return decompileInstructions(t.Transaction.Message, t.Meta)
+}
+
+// TransactionError parses Meta.Err and names custom errors of known programs by
+// DefaultProgramErrorCatalog. it returns nil if the transaction succeeded.
+func (t Transaction) TransactionError() (*types.TransactionError, error) {
+	return resolveTransactionError(t.Meta, t.Transaction.Message)
+}
+
 type TransactionMeta struct {
 	Err                  any
 	Fee                  uint64
@@ -53,6 +64,11 @@ type TransactionMeta struct {
 	ComputeUnitsConsumed *uint64
 }
 
+// TransactionError parses Err into a typed error. it returns nil if the transaction succeeded.
+func (m TransactionMeta) TransactionError() (*types.TransactionError, error) {
+	return types.ParseTransactionError(m.Err)
+}
+
 type InnerInstruction struct {
 	Index        uint64
 	Instructions []types.CompiledInstruction
@@ -172,6 +188,18 @@ func convertTransactionMeta(meta *rpc.TransactionMeta) (*TransactionMeta, error)
 	}, nil
 }
 
+func resolveTransactionError(meta *TransactionMeta, message types.Message) (*types.TransactionError, error) {
+	if meta == nil {
+		return nil, nil
+	}
+	txErr, err := meta.TransactionError()
+	if err != nil {
+		return nil, err
+	}
+	DefaultProgramErrorCatalog.Resolve(txErr, message)
+	return txErr, nil
+}
+
 func parseBase64Tx(raw any, transactionMeta *TransactionMeta) (types.Transaction, []common.PublicKey, error) {
 	// transaction
 	data, ok := raw.([]any)
@@ -208,3 +236,22 @@ func parseBase64Tx(raw any, transactionMeta *TransactionMeta) (types.Transaction
 
 	return tx, accountKeys, nil
 }
+
+func decompileInstructions(message types.Message, meta *TransactionMeta) ([]types.Instruction, error) {
+	var loaded types.LoadedAddresses
+	if meta != nil {
+		loaded = convertLoadedAddresses(meta.LoadedAddresses)
+	}
+	return message.DecompileInstructionsWithLoadedAddresses(loaded)
+}
+
+func convertLoadedAddresses(v rpc.TransactionLoadedAddresses) types.LoadedAddresses {
+	var loaded types.LoadedAddresses
+	for _, s := range v.Writable {
+		loaded.Writable = append(loaded.Writable, common.PublicKeyFromString(s))
+	}
+	for _, s := range v.Readonly {
+		loaded.Readonly = append(loaded.Readonly, common.PublicKeyFromString(s))
+	}
+	return loaded
+}
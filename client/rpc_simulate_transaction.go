@@ -17,6 +17,13 @@ type SimulateTransaction struct {
 	UnitConsumed *uint64
 }
 
+// TransactionError parses Err into a typed error. it returns nil if the simulation succeeded.
+// pass the result and the simulated message to DefaultProgramErrorCatalog.Resolve to name
+// custom errors of known programs.
+func (s SimulateTransaction) TransactionError() (*types.TransactionError, error) {
+	return types.ParseTransactionError(s.Err)
+}
+
 type SimulateTransactionConfig struct {
 	SigVerify              bool
 	Commitment             rpc.Commitment
@@ -8,6 +8,7 @@ import (
 	"github.com/blocto/solana-go-sdk/common"
 	"github.com/blocto/solana-go-sdk/rpc"
 	"github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func mustDeserializeBase64Tx(t *testing.T, s string) types.Transaction {
@@ -58,3 +59,10 @@ func Test_convertReturnData(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenAmount_DecimalAmount(t *testing.T) {
+	tokenAmount, err := newTokenAmount("1500000", 6, "1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, common.NewAmount(1_500_000, 6), tokenAmount.DecimalAmount())
+	assert.Equal(t, tokenAmount.UIAmountString, tokenAmount.DecimalAmount().String())
+}
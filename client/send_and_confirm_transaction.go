@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+)
+
+var (
+	// ErrTransactionExpired means the block height passed the last valid block
+	// height of the blockhash and the transaction never landed
+	ErrTransactionExpired = errors.New("transaction expired, block height exceeded last valid block height")
+	// ErrTransactionTimeout means the transaction didn't reach the commitment in time,
+	// it may still land if the blockhash is valid
+	ErrTransactionTimeout = errors.New("transaction confirmation timed out")
+)
+
+// TransactionFailedError means the transaction landed but failed on chain
+type TransactionFailedError struct {
+	Signature string
+	Slot      uint64
+	Err       *types.TransactionError
+}
+
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %v failed, err: %v", e.Signature, e.Err)
+}
+
+func (e *TransactionFailedError) Unwrap() error {
+	return e.Err
+}
+
+type SendAndConfirmTransactionConfig struct {
+	// Commitment is the commitment to wait for, default: confirmed
+	Commitment rpc.Commitment
+	// LastValidBlockHeight is from GetLatestBlockhash of the tx's blockhash. zero
+	// disables expiry tracking, set a Timeout or a ctx deadline then.
+	LastValidBlockHeight uint64
+	SkipPreflight        bool
+	PreflightCommitment  rpc.Commitment
+	// RebroadcastInterval is how often the raw tx is sent again, default: 2s
+	RebroadcastInterval time.Duration
+	// PollInterval is how often the status is checked, default: 500ms
+	PollInterval time.Duration
+	// Timeout zero means no timeout other than expiry and ctx
+	Timeout time.Duration
+	// OnStatus is called once each time the tx reaches processed, confirmed and finalized
+	OnStatus func(signature string, commitment rpc.Commitment)
+}
+
+// SendAndConfirmTransaction sends the tx and waits until it reaches the commitment.
+// the raw tx is rebroadcasted on an interval with max retries 0 so the node doesn't
+// retry on its own. the signature is always returned once the first send succeeds.
+// the error is ErrTransactionExpired, ErrTransactionTimeout, a *TransactionFailedError,
+// or an error from the rpc calls.
+func (c *Client) SendAndConfirmTransaction(ctx context.Context, tx types.Transaction, cfg SendAndConfirmTransactionConfig) (string, error) {
+	if cfg.LastValidBlockHeight == 0 {
+		return c.sendAndConfirmTransaction(ctx, tx, cfg, nil)
+	}
+	return c.sendAndConfirmTransaction(ctx, tx, cfg, func(ctx context.Context) error {
+		blockHeight, err := c.GetBlockHeightWithConfig(ctx, GetBlockHeightConfig{Commitment: rpc.CommitmentConfirmed})
+		if err == nil && blockHeight > cfg.LastValidBlockHeight {
+			return ErrTransactionExpired
+		}
+		return nil
+	})
+}
+
+// sendAndConfirmTransaction returns the error of expired if the tx can't land anymore
+func (c *Client) sendAndConfirmTransaction(ctx context.Context, tx types.Transaction, cfg SendAndConfirmTransactionConfig, expired func(context.Context) error) (string, error) {
+	if len(tx.Signatures) == 0 {
+		return "", errors.New("tx has no signature")
+	}
+	if cfg.Commitment == "" {
+		cfg.Commitment = rpc.CommitmentConfirmed
+	}
+	if cfg.RebroadcastInterval <= 0 {
+		cfg.RebroadcastInterval = 2 * time.Second
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 500 * time.Millisecond
+	}
+
+	rawTx, err := tx.Serialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize tx, err: %v", err)
+	}
+	encodedTx := base64.StdEncoding.EncodeToString(rawTx)
+	signature := base58.Encode(tx.Signatures[0])
+
+	sendCfg := rebroadcastSendConfig{
+		SendTransactionConfig: rpc.SendTransactionConfig{
+			Encoding:            rpc.SendTransactionConfigEncodingBase64,
+			SkipPreflight:       cfg.SkipPreflight,
+			PreflightCommitment: cfg.PreflightCommitment,
+		},
+	}
+	_, err = process(
+		func() (rpc.JsonRpcResponse[string], error) {
+			return c.sendRebroadcastTransaction(ctx, encodedTx, sendCfg)
+		},
+		forward[string],
+	)
+	if err != nil {
+		return "", err
+	}
+	// preflight has been done by the first send
+	sendCfg.SkipPreflight = true
+
+	var timeout <-chan time.Time
+	if cfg.Timeout > 0 {
+		t := time.NewTimer(cfg.Timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	poll := time.NewTicker(cfg.PollInterval)
+	defer poll.Stop()
+	rebroadcast := time.NewTicker(cfg.RebroadcastInterval)
+	defer rebroadcast.Stop()
+
+	reached := -1
+	for {
+		select {
+		case <-ctx.Done():
+			return signature, ctx.Err()
+		case <-timeout:
+			return signature, ErrTransactionTimeout
+		case <-rebroadcast.C:
+			// the status poll tells whether it landed, errors here don't matter
+			_, _ = c.sendRebroadcastTransaction(ctx, encodedTx, sendCfg)
+			continue
+		case <-poll.C:
+		}
+
+		done, _, err := c.checkSignatureStatus(ctx, signature, tx.Message, cfg, &reached)
+		if done || err != nil {
+			return signature, err
+		}
+
+		if expired == nil {
+			continue
+		}
+		if expiredErr := expired(ctx); expiredErr != nil {
+			// it may have landed right before it expired, e.g. a nonce tx advances the
+			// nonce itself. once it has landed keep polling for the commitment.
+			done, landed, err := c.checkSignatureStatus(ctx, signature, tx.Message, cfg, &reached)
+			if done || err != nil {
+				return signature, err
+			}
+			if landed {
+				continue
+			}
+			return signature, expiredErr
+		}
+	}
+}
+
+// checkSignatureStatus returns done if the tx reached the commitment and landed if the
+// node knows the tx at any commitment, reached is the highest commitment level
+// reported to OnStatus
+// rebroadcastSendConfig always sends maxRetries, 0 stops the node from retrying
+// the transaction by itself since the client rebroadcasts it
+type rebroadcastSendConfig struct {
+	rpc.SendTransactionConfig
+	MaxRetries uint64 `json:"maxRetries"`
+}
+
+func (c *Client) sendRebroadcastTransaction(ctx context.Context, encodedTx string, cfg rebroadcastSendConfig) (rpc.JsonRpcResponse[string], error) {
+	var res rpc.JsonRpcResponse[string]
+	body, err := c.RpcClient.Call(ctx, "sendTransaction", encodedTx, cfg)
+	if err != nil {
+		return res, fmt.Errorf("rpc: call error, err: %w, body: %v", err, string(body))
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return res, fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+	}
+	return res, nil
+}
+
+func (c *Client) checkSignatureStatus(ctx context.Context, signature string, message types.Message, cfg SendAndConfirmTransactionConfig, reached *int) (done bool, landed bool, err error) {
+	statuses, err := c.GetSignatureStatuses(ctx, []string{signature})
+	if err != nil || len(statuses) == 0 || statuses[0] == nil {
+		// keep polling, a temporary rpc error shouldn't end the confirmation
+		return false, false, nil
+	}
+	status := statuses[0]
+
+	if status.Err != nil {
+		txErr, err := types.ParseTransactionError(status.Err)
+		if err != nil {
+			return false, true, fmt.Errorf("failed to parse transaction error, err: %v", err)
+		}
+		DefaultProgramErrorCatalog.Resolve(txErr, message)
+		return false, true, &TransactionFailedError{Signature: signature, Slot: status.Slot, Err: txErr}
+	}
+
+	level := commitmentLevel(signatureStatusCommitment(status))
+	for *reached < level {
+		*reached++
+		if cfg.OnStatus != nil {
+			cfg.OnStatus(signature, commitmentLevels[*reached])
+		}
+	}
+	return level >= commitmentLevel(cfg.Commitment), true, nil
+}
+
+var commitmentLevels = []rpc.Commitment{rpc.CommitmentProcessed, rpc.CommitmentConfirmed, rpc.CommitmentFinalized}
+
+func commitmentLevel(commitment rpc.Commitment) int {
+	for i, c := range commitmentLevels {
+		if c == commitment {
+			return i
+		}
+	}
+	return 0
+}
+
+func signatureStatusCommitment(status *rpc.SignatureStatus) rpc.Commitment {
+	if status.ConfirmationStatus != nil {
+		return *status.ConfirmationStatus
+	}
+	// nodes without confirmationStatus report nil confirmations for rooted txs
+	if status.Confirmations == nil {
+		return rpc.CommitmentFinalized
+	}
+	return rpc.CommitmentProcessed
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmServer answers sendTransaction, getSignatureStatuses, getBlockHeight and
+// getAccountInfo. statuses are returned in order and the last one repeats. results
+// overrides any method and answers the same way.
+type confirmServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	statuses    []string
+	blockHeight uint64
+	accountInfo string
+	results     map[string][]string
+	sends       []json.RawMessage
+	rawTxs      []string
+}
+
+func newConfirmServer(t *testing.T, statuses []string, blockHeight uint64) *confirmServer {
+	s := &confirmServer{statuses: statuses, blockHeight: blockHeight}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var result string
+		if results, ok := s.results[body.Method]; ok {
+			result = results[0]
+			if len(results) > 1 {
+				s.results[body.Method] = results[1:]
+			}
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+			return
+		}
+		switch body.Method {
+		case "sendTransaction":
+			s.sends = append(s.sends, body.Params[1])
+			var rawTx string
+			_ = json.Unmarshal(body.Params[0], &rawTx)
+			s.rawTxs = append(s.rawTxs, rawTx)
+			result = `"sig"`
+		case "getSignatureStatuses":
+			result = `{"context":{"slot":100},"value":[` + s.statuses[0] + `]}`
+			if len(s.statuses) > 1 {
+				s.statuses = s.statuses[1:]
+			}
+		case "getBlockHeight":
+			b, _ := json.Marshal(s.blockHeight)
+			result = string(b)
+		case "getAccountInfo":
+			result = `{"context":{"slot":100},"value":` + s.accountInfo + `}`
+		default:
+			t.Errorf("unexpected method %v", body.Method)
+		}
+		_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *confirmServer) sentTxs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.rawTxs...)
+}
+
+func (s *confirmServer) sendConfigs() []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]json.RawMessage{}, s.sends...)
+}
+
+func TestClient_SendAndConfirmTransaction(t *testing.T) {
+	tx := mustDeserializeBase64Tx(t, "Ab/yMEK7qNgGxaPMg2XaVnwwLMqnY8FTeJrA9qJ1nOBFX08BHycnp3/9WOxOY53+eZnbkT2/+6Mx7w+DsuVN8ggBAAECBj5w2ZFXmNyj7tuRN89kxw/6+2LN04KBBSUL12sdbN4e0EmQh0otX6HS7HumAryrMtxCzacgpjtG6MY9cJWYYEsGZsdWhvaw9ENEPFBEi4eBna4CphPQWWcgU4yARSnVAQEAAA==")
+	signature := "4qajzehMVKUQiC3Dyo26JqKjahFf7PZMfYuoykPEA3DztgyYRH3xa9FtjsYZ2usGnTnqi5NHS1owRVKMK9CCN1d9"
+
+	t.Run("confirmed", func(t *testing.T) {
+		s := newConfirmServer(t, []string{
+			`null`,
+			`{"slot":1,"confirmations":0,"confirmationStatus":"processed","err":null}`,
+			`{"slot":1,"confirmations":1,"confirmationStatus":"confirmed","err":null}`,
+		}, 0)
+
+		var reached []rpc.Commitment
+		sig, err := NewClient(s.URL).SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			LastValidBlockHeight: 10,
+			PollInterval:         time.Millisecond,
+			OnStatus: func(signature string, commitment rpc.Commitment) {
+				reached = append(reached, commitment)
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, signature, sig)
+		assert.Equal(t, []rpc.Commitment{rpc.CommitmentProcessed, rpc.CommitmentConfirmed}, reached)
+
+		sends := s.sendConfigs()
+		require.NotEmpty(t, sends)
+		assert.JSONEq(t, `{"encoding":"base64","maxRetries":0}`, string(sends[0]))
+	})
+
+	t.Run("finalized reports every level", func(t *testing.T) {
+		s := newConfirmServer(t, []string{
+			`{"slot":1,"confirmations":null,"confirmationStatus":"finalized","err":null}`,
+		}, 0)
+
+		var reached []rpc.Commitment
+		_, err := NewClient(s.URL).SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			Commitment:   rpc.CommitmentFinalized,
+			PollInterval: time.Millisecond,
+			OnStatus: func(signature string, commitment rpc.Commitment) {
+				reached = append(reached, commitment)
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []rpc.Commitment{rpc.CommitmentProcessed, rpc.CommitmentConfirmed, rpc.CommitmentFinalized}, reached)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		s := newConfirmServer(t, []string{
+			`{"slot":7,"confirmations":0,"confirmationStatus":"processed","err":{"InstructionError":[0,{"Custom":1}]}}`,
+		}, 0)
+
+		sig, err := NewClient(s.URL).SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			PollInterval: time.Millisecond,
+		})
+		assert.Equal(t, signature, sig)
+		var failedErr *TransactionFailedError
+		require.True(t, errors.As(err, &failedErr))
+		assert.Equal(t, uint64(7), failedErr.Slot)
+		assert.EqualError(t, err, "transaction "+signature+" failed, err: Error processing Instruction 0: custom program error: 0x1")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		s := newConfirmServer(t, []string{`null`}, 11)
+
+		sig, err := NewClient(s.URL).SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			LastValidBlockHeight: 10,
+			PollInterval:         time.Millisecond,
+		})
+		assert.Equal(t, signature, sig)
+		assert.ErrorIs(t, err, ErrTransactionExpired)
+	})
+
+	t.Run("timeout and rebroadcast", func(t *testing.T) {
+		s := newConfirmServer(t, []string{`null`}, 0)
+
+		sig, err := NewClient(s.URL).SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			LastValidBlockHeight: 10,
+			PollInterval:         time.Millisecond,
+			RebroadcastInterval:  5 * time.Millisecond,
+			Timeout:              100 * time.Millisecond,
+		})
+		assert.Equal(t, signature, sig)
+		assert.ErrorIs(t, err, ErrTransactionTimeout)
+
+		sends := s.sendConfigs()
+		require.Greater(t, len(sends), 1)
+		for _, cfg := range sends[1:] {
+			assert.JSONEq(t, `{"encoding":"base64","skipPreflight":true,"maxRetries":0}`, string(cfg))
+		}
+	})
+
+	t.Run("no signature", func(t *testing.T) {
+		_, err := NewClient("").SendAndConfirmTransaction(context.Background(), types.Transaction{}, SendAndConfirmTransactionConfig{})
+		assert.EqualError(t, err, "tx has no signature")
+	})
+}
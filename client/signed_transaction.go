@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/rpc"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+type NewSignedTransactionParam struct {
+	FeePayer                   common.PublicKey
+	Instructions               []types.Instruction
+	AddressLookupTableAccounts []types.AddressLookupTableAccount
+	// Signers can be local accounts or remote signers, all required signers must be given
+	Signers []types.Signer
+	// Commitment of the latest blockhash, default: the rpc default
+	Commitment rpc.Commitment
+}
+
+// NewSignedTransaction builds a tx with the latest blockhash and signs it by the signers.
+// it also returns the last valid block height for SendAndConfirmTransaction.
+func (c *Client) NewSignedTransaction(ctx context.Context, param NewSignedTransactionParam) (types.Transaction, uint64, error) {
+	latestBlockhash, err := c.GetLatestBlockhashWithConfig(ctx, GetLatestBlockhashConfig{Commitment: param.Commitment})
+	if err != nil {
+		return types.Transaction{}, 0, fmt.Errorf("failed to get latest blockhash, err: %w", err)
+	}
+	tx, err := types.NewTransactionWithSigners(ctx, types.NewTransactionWithSignersParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:                   param.FeePayer,
+			Instructions:               param.Instructions,
+			RecentBlockhash:            latestBlockhash.Blockhash,
+			AddressLookupTableAccounts: param.AddressLookupTableAccounts,
+		}),
+		Signers: param.Signers,
+	})
+	if err != nil {
+		return types.Transaction{}, 0, fmt.Errorf("failed to create new tx, err: %w", err)
+	}
+	if missing := tx.MissingSigners(); len(missing) > 0 {
+		return types.Transaction{}, 0, fmt.Errorf("missing signature of %v", missing[0])
+	}
+	return tx, latestBlockhash.LatestValidBlockHeight, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/blocto/solana-go-sdk/program/memo"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remoteSigner never exposes its private key
+type remoteSigner struct {
+	account types.Account
+}
+
+func (s remoteSigner) Public() common.PublicKey {
+	return s.account.PublicKey
+}
+
+func (s remoteSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	return s.account.Sign(message), nil
+}
+
+func TestClient_NewSignedTransaction(t *testing.T) {
+	feePayer := types.NewAccount()
+	remote := remoteSigner{account: types.NewAccount()}
+	instruction := memo.BuildMemo(memo.BuildMemoParam{
+		SignerPubkeys: []common.PublicKey{remote.Public()},
+		Memo:          []byte("remote"),
+	})
+	requestBody := `{"jsonrpc":"2.0", "id":1, "method":"getLatestBlockhash", "params":[{"commitment": "confirmed"}]}`
+	responseBody := `{"jsonrpc":"2.0","result":{"context":{"apiVersion":"1.14.10","slot":187545846},"value":{"blockhash":"DjQ4csyDJ9ZQvNNbK838ATs5UrqMq8s4Pd5i1ts22HAQ","lastValidBlockHeight":177067026}},"id":1}`
+
+	client_test.Test(t, client_test.Param{
+		Name:         "signed",
+		RequestBody:  requestBody,
+		ResponseBody: responseBody,
+		F: func(url string) (any, error) {
+			tx, lastValidBlockHeight, err := NewClient(url).NewSignedTransaction(context.Background(), NewSignedTransactionParam{
+				FeePayer:     feePayer.PublicKey,
+				Instructions: []types.Instruction{instruction},
+				Signers:      []types.Signer{feePayer, remote},
+				Commitment:   "confirmed",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, uint64(177067026), lastValidBlockHeight)
+			assert.Equal(t, "DjQ4csyDJ9ZQvNNbK838ATs5UrqMq8s4Pd5i1ts22HAQ", tx.Message.RecentBlockHash)
+			data, err := tx.Message.Serialize()
+			require.NoError(t, err)
+			require.Len(t, tx.Signatures, 2)
+			for i, sig := range tx.Signatures {
+				assert.True(t, ed25519.Verify(tx.Message.Accounts[i].Bytes(), data, sig))
+			}
+			return nil, nil
+		},
+		ExpectedValue: nil,
+		ExpectedError: nil,
+	})
+
+	client_test.Test(t, client_test.Param{
+		Name:         "missing signer",
+		RequestBody:  requestBody,
+		ResponseBody: responseBody,
+		F: func(url string) (any, error) {
+			_, _, err := NewClient(url).NewSignedTransaction(context.Background(), NewSignedTransactionParam{
+				FeePayer:     feePayer.PublicKey,
+				Instructions: []types.Instruction{instruction},
+				Signers:      []types.Signer{feePayer},
+				Commitment:   "confirmed",
+			})
+			return nil, err
+		},
+		ExpectedValue: nil,
+		ExpectedError: errors.New("missing signature of " + remote.Public().ToBase58()),
+	})
+}
@@ -0,0 +1,220 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/rpc"
+	"github.com/blocto/solana-go-sdk/ws"
+)
+
+var (
+	ErrUnknownCluster   = errors.New("cluster: unknown cluster")
+	ErrClusterMismatch  = errors.New("cluster: cluster mismatch")
+	ErrClusterExists    = errors.New("cluster: cluster already registered")
+	ErrClusterNameEmpty = errors.New("cluster: cluster name is empty")
+)
+
+// Cluster describes a solana cluster
+type Cluster struct {
+	Name        string
+	RPCEndpoint string
+	WSEndpoint  string
+	// GenesisHash identifies the cluster behind an endpoint, empty means it can't be
+	// detected, e.g. a local test validator has a new genesis every time
+	GenesisHash string
+	// ProgramIDs overrides the program ids of common, which are the mainnet ones, for
+	// programs deployed at another address on this cluster
+	ProgramIDs map[common.PublicKey]common.PublicKey
+}
+
+// ProgramID returns the address of the program on this cluster, id is the mainnet one
+func (c Cluster) ProgramID(id common.PublicKey) common.PublicKey {
+	if override, ok := c.ProgramIDs[id]; ok {
+		return override
+	}
+	return id
+}
+
+func (c Cluster) String() string {
+	return c.Name
+}
+
+// clone copies ProgramIDs so the registry doesn't share the map with callers
+func (c Cluster) clone() Cluster {
+	if c.ProgramIDs != nil {
+		programIDs := make(map[common.PublicKey]common.PublicKey, len(c.ProgramIDs))
+		for k, v := range c.ProgramIDs {
+			programIDs[k] = v
+		}
+		c.ProgramIDs = programIDs
+	}
+	return c
+}
+
+var (
+	Mainnet = Cluster{
+		Name:        "mainnet-beta",
+		RPCEndpoint: rpc.MainnetRPCEndpoint,
+		WSEndpoint:  ws.MainnetWSEndpoint,
+		GenesisHash: "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d",
+	}
+	Devnet = Cluster{
+		Name:        "devnet",
+		RPCEndpoint: rpc.DevnetRPCEndpoint,
+		WSEndpoint:  ws.DevnetWSEndpoint,
+		GenesisHash: "EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG",
+	}
+	Testnet = Cluster{
+		Name:        "testnet",
+		RPCEndpoint: rpc.TestnetRPCEndpoint,
+		WSEndpoint:  ws.TestnetWSEndpoint,
+		GenesisHash: "4uhcVJyU9pJkvQyS88uRDiswHXSCkY3zQawwpjk2NsNY",
+	}
+	Localnet = Cluster{
+		Name:        "localnet",
+		RPCEndpoint: rpc.LocalnetRPCEndpoint,
+		WSEndpoint:  ws.LocalnetWSEndpoint,
+	}
+)
+
+// Registry holds clusters by name and genesis hash, it is safe for concurrent use
+type Registry struct {
+	mu        sync.RWMutex
+	byName    map[string]Cluster
+	byGenesis map[string]string
+}
+
+// DefaultRegistry has mainnet-beta, devnet, testnet and localnet
+var DefaultRegistry = MustNewRegistry(Mainnet, Devnet, Testnet, Localnet)
+
+func NewRegistry(clusters ...Cluster) (*Registry, error) {
+	r := &Registry{
+		byName:    map[string]Cluster{},
+		byGenesis: map[string]string{},
+	}
+	for _, cluster := range clusters {
+		if err := r.Register(cluster); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func MustNewRegistry(clusters ...Cluster) *Registry {
+	r, err := NewRegistry(clusters...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Register adds a cluster, names and genesis hashes must be unique
+func (r *Registry) Register(cluster Cluster) error {
+	if cluster.Name == "" {
+		return ErrClusterNameEmpty
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byName[cluster.Name]; ok {
+		return fmt.Errorf("%w, name: %v", ErrClusterExists, cluster.Name)
+	}
+	if cluster.GenesisHash != "" {
+		if name, ok := r.byGenesis[cluster.GenesisHash]; ok {
+			return fmt.Errorf("%w, genesis hash: %v, name: %v", ErrClusterExists, cluster.GenesisHash, name)
+		}
+		r.byGenesis[cluster.GenesisHash] = cluster.Name
+	}
+	r.byName[cluster.Name] = cluster.clone()
+	return nil
+}
+
+// Get returns the cluster by name
+func (r *Registry) Get(name string) (Cluster, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cluster, ok := r.byName[name]
+	return cluster.clone(), ok
+}
+
+// GetByGenesisHash returns the cluster by genesis hash
+func (r *Registry) GetByGenesisHash(genesisHash string) (Cluster, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byGenesis[genesisHash]
+	if !ok {
+		return Cluster{}, false
+	}
+	return r.byName[name].clone(), true
+}
+
+// Clusters returns the registered clusters sorted by name
+func (r *Registry) Clusters() []Cluster {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clusters := make([]Cluster, 0, len(r.byName))
+	for _, cluster := range r.byName {
+		clusters = append(clusters, cluster.clone())
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters
+}
+
+// Detect asks the endpoint for its genesis hash and returns the cluster of it
+func (r *Registry) Detect(ctx context.Context, endpoint string) (Cluster, error) {
+	return r.DetectClient(ctx, client.NewClient(endpoint))
+}
+
+// DetectClient is Detect with an existing client
+func (r *Registry) DetectClient(ctx context.Context, c *client.Client) (Cluster, error) {
+	genesisHash, err := c.GetGenesisHash(ctx)
+	if err != nil {
+		return Cluster{}, fmt.Errorf("failed to get genesis hash, err: %w", err)
+	}
+	cluster, ok := r.GetByGenesisHash(genesisHash)
+	if !ok {
+		return Cluster{}, fmt.Errorf("%w, genesis hash: %v", ErrUnknownCluster, genesisHash)
+	}
+	return cluster, nil
+}
+
+// Expect returns ErrClusterMismatch if the endpoint isn't the expected cluster, e.g.
+// call it before sending transactions built for devnet
+func (r *Registry) Expect(ctx context.Context, endpoint string, expected Cluster) error {
+	return r.ExpectClient(ctx, client.NewClient(endpoint), expected)
+}
+
+// ExpectClient is Expect with an existing client
+func (r *Registry) ExpectClient(ctx context.Context, c *client.Client, expected Cluster) error {
+	if expected.GenesisHash == "" {
+		return fmt.Errorf("%w, %v has no genesis hash", ErrUnknownCluster, expected.Name)
+	}
+	genesisHash, err := c.GetGenesisHash(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get genesis hash, err: %w", err)
+	}
+	if genesisHash != expected.GenesisHash {
+		got := genesisHash
+		if cluster, ok := r.GetByGenesisHash(genesisHash); ok {
+			got = cluster.Name
+		}
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrClusterMismatch, expected.Name, got)
+	}
+	return nil
+}
+
+// Detect uses DefaultRegistry
+func Detect(ctx context.Context, endpoint string) (Cluster, error) {
+	return DefaultRegistry.Detect(ctx, endpoint)
+}
+
+// Expect uses DefaultRegistry
+func Expect(ctx context.Context, endpoint string, expected Cluster) error {
+	return DefaultRegistry.Expect(ctx, endpoint, expected)
+}
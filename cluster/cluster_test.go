@@ -0,0 +1,129 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+	"github.com/stretchr/testify/assert"
+)
+
+func genesisHashResponse(genesisHash string) string {
+	return `{"jsonrpc":"2.0","result":"` + genesisHash + `","id":1}`
+}
+
+const genesisHashRequest = `{"jsonrpc":"2.0", "id":1, "method":"getGenesisHash"}`
+
+func TestDetect(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name:         "devnet",
+				RequestBody:  genesisHashRequest,
+				ResponseBody: genesisHashResponse("EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG"),
+				F: func(url string) (any, error) {
+					return Detect(context.Background(), url)
+				},
+				ExpectedValue: Devnet,
+				ExpectedError: nil,
+			},
+			{
+				Name:         "unknown",
+				RequestBody:  genesisHashRequest,
+				ResponseBody: genesisHashResponse("8uhcVJyU9pJkvQyS88uRDiswHXSCkY3zQawwpjk2NsNY"),
+				F: func(url string) (any, error) {
+					return Detect(context.Background(), url)
+				},
+				ExpectedValue: Cluster{},
+				ExpectedError: fmt.Errorf("%w, genesis hash: 8uhcVJyU9pJkvQyS88uRDiswHXSCkY3zQawwpjk2NsNY", ErrUnknownCluster),
+			},
+		},
+	)
+}
+
+func TestExpect(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name:         "match",
+				RequestBody:  genesisHashRequest,
+				ResponseBody: genesisHashResponse("5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d"),
+				F: func(url string) (any, error) {
+					return nil, Expect(context.Background(), url, Mainnet)
+				},
+				ExpectedValue: nil,
+				ExpectedError: nil,
+			},
+			{
+				Name:         "mismatch",
+				RequestBody:  genesisHashRequest,
+				ResponseBody: genesisHashResponse("5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d"),
+				F: func(url string) (any, error) {
+					return nil, Expect(context.Background(), url, Devnet)
+				},
+				ExpectedValue: nil,
+				ExpectedError: fmt.Errorf("%w, expected: devnet, got: mainnet-beta", ErrClusterMismatch),
+			},
+		},
+	)
+
+	err := Expect(context.Background(), "", Localnet)
+	assert.ErrorIs(t, err, ErrUnknownCluster)
+}
+
+func TestRegistry(t *testing.T) {
+	programID := common.PublicKeyFromString("DeV111111111111111111111111111111111111111")
+	custom := Cluster{
+		Name:        "custom",
+		RPCEndpoint: "http://localhost:8899",
+		GenesisHash: "8uhcVJyU9pJkvQyS88uRDiswHXSCkY3zQawwpjk2NsNY",
+		ProgramIDs:  map[common.PublicKey]common.PublicKey{common.MemoProgramID: programID},
+	}
+
+	r, err := NewRegistry(Mainnet, custom)
+	assert.Nil(t, err)
+
+	got, ok := r.Get("custom")
+	assert.True(t, ok)
+	assert.Equal(t, custom, got)
+	got, ok = r.GetByGenesisHash(Mainnet.GenesisHash)
+	assert.True(t, ok)
+	assert.Equal(t, Mainnet, got)
+	_, ok = r.Get("devnet")
+	assert.False(t, ok)
+	assert.Equal(t, []Cluster{custom, Mainnet}, r.Clusters())
+
+	assert.Equal(t, programID, custom.ProgramID(common.MemoProgramID))
+	assert.Equal(t, common.MemoProgramID, Mainnet.ProgramID(common.MemoProgramID))
+	assert.Equal(t, common.TokenProgramID, custom.ProgramID(common.TokenProgramID))
+
+	assert.ErrorIs(t, r.Register(Cluster{Name: "mainnet-beta"}), ErrClusterExists)
+	assert.EqualError(t, r.Register(Cluster{Name: "other", GenesisHash: Mainnet.GenesisHash}), "cluster: cluster already registered, genesis hash: 5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d, name: mainnet-beta")
+	assert.ErrorIs(t, r.Register(Cluster{}), ErrClusterNameEmpty)
+
+	// clusters without genesis hash don't collide
+	assert.Nil(t, r.Register(Cluster{Name: "local1"}))
+	assert.Nil(t, r.Register(Cluster{Name: "local2"}))
+}
+
+func TestRegistry_ProgramIDsAreCopied(t *testing.T) {
+	programID := common.PublicKeyFromString("DeV111111111111111111111111111111111111111")
+	custom := Cluster{
+		Name:       "custom",
+		ProgramIDs: map[common.PublicKey]common.PublicKey{common.MemoProgramID: programID},
+	}
+	r := MustNewRegistry(custom)
+
+	// neither the registered map nor a returned one changes the registry
+	custom.ProgramIDs[common.TokenProgramID] = programID
+	got, _ := r.Get("custom")
+	got.ProgramIDs[common.MemoProgramID] = common.MemoProgramID
+	r.Clusters()[0].ProgramIDs[common.Token2022ProgramID] = programID
+
+	got, _ = r.Get("custom")
+	assert.Equal(t, map[common.PublicKey]common.PublicKey{common.MemoProgramID: programID}, got.ProgramIDs)
+}
@@ -0,0 +1,257 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SOLDecimals is the number of decimals of SOL, 1 SOL = 10^9 lamports
+	SOLDecimals = 9
+	// LamportsPerSOL is the number of lamports in 1 SOL
+	LamportsPerSOL = 1_000_000_000
+	// MaxAmountDecimals is the most decimals a u64 amount can have, 10^19 fits in u64
+	MaxAmountDecimals = 19
+)
+
+var (
+	ErrAmountInvalid          = errors.New("invalid amount")
+	ErrAmountOverflow         = errors.New("amount overflow")
+	ErrAmountUnderflow        = errors.New("amount underflow")
+	ErrAmountTooPrecise       = errors.New("amount has more decimals than allowed")
+	ErrAmountDecimalsMismatch = errors.New("amount decimals mismatch")
+	ErrAmountInvalidDecimals  = errors.New("invalid amount decimals")
+)
+
+var pow10 = func() [MaxAmountDecimals + 1]uint64 {
+	var p [MaxAmountDecimals + 1]uint64
+	p[0] = 1
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1] * 10
+	}
+	return p
+}()
+
+// Amount is an exact decimal amount, the value is Raw / 10^Decimals. e.g. lamports
+// are Raw with 9 decimals, token amounts are Raw with the decimals of the mint.
+type Amount struct {
+	Raw      uint64
+	Decimals uint8
+}
+
+func NewAmount(raw uint64, decimals uint8) Amount {
+	return Amount{Raw: raw, Decimals: decimals}
+}
+
+// Lamports returns the amount of lamports in SOL
+func Lamports(lamports uint64) Amount {
+	return Amount{Raw: lamports, Decimals: SOLDecimals}
+}
+
+// ParseSOL parses a SOL amount like "1.5"
+func ParseSOL(s string) (Amount, error) {
+	return ParseAmount(s, SOLDecimals)
+}
+
+// ParseAmount parses a human amount like "1.5" without rounding, it fails if the
+// fraction has more digits than decimals
+func ParseAmount(s string, decimals uint8) (Amount, error) {
+	if decimals > MaxAmountDecimals {
+		return Amount{}, fmt.Errorf("%w, decimals: %v", ErrAmountInvalidDecimals, decimals)
+	}
+
+	integer, fraction, hasPoint := strings.Cut(s, ".")
+	if (integer == "" && fraction == "") || (hasPoint && fraction == "") || !isDigits(integer) || !isDigits(fraction) {
+		return Amount{}, fmt.Errorf("%w, amount: %q", ErrAmountInvalid, s)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decimals) {
+		return Amount{}, fmt.Errorf("%w, amount: %v, decimals: %v", ErrAmountTooPrecise, s, decimals)
+	}
+
+	var raw uint64
+	if integer = strings.TrimLeft(integer, "0"); integer != "" {
+		n, err := strconv.ParseUint(integer, 10, 64)
+		if err != nil {
+			return Amount{}, fmt.Errorf("%w, amount: %v", ErrAmountOverflow, s)
+		}
+		hi, lo := bits.Mul64(n, pow10[decimals])
+		if hi != 0 {
+			return Amount{}, fmt.Errorf("%w, amount: %v", ErrAmountOverflow, s)
+		}
+		raw = lo
+	}
+	if fraction != "" {
+		n, err := strconv.ParseUint(fraction, 10, 64)
+		if err != nil {
+			return Amount{}, fmt.Errorf("%w, amount: %q", ErrAmountInvalid, s)
+		}
+		var carry uint64
+		raw, carry = bits.Add64(raw, n*pow10[int(decimals)-len(fraction)], 0)
+		if carry != 0 {
+			return Amount{}, fmt.Errorf("%w, amount: %v", ErrAmountOverflow, s)
+		}
+	}
+	return Amount{Raw: raw, Decimals: decimals}, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount without trailing zeros, the same as uiAmountString of rpc
+func (a Amount) String() string {
+	s := a.FixedString()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// FixedString formats the amount with all decimals, e.g. "1.500000000"
+func (a Amount) FixedString() string {
+	s := strconv.FormatUint(a.Raw, 10)
+	if a.Decimals == 0 {
+		return s
+	}
+	if len(s) <= int(a.Decimals) {
+		s = strings.Repeat("0", int(a.Decimals)-len(s)+1) + s
+	}
+	point := len(s) - int(a.Decimals)
+	return s[:point] + "." + s[point:]
+}
+
+func (a Amount) IsZero() bool {
+	return a.Raw == 0
+}
+
+// Lamports returns the raw amount if the amount is in SOL
+func (a Amount) Lamports() (uint64, error) {
+	if a.Decimals != SOLDecimals {
+		return 0, fmt.Errorf("%w, expected: %v, got: %v", ErrAmountDecimalsMismatch, SOLDecimals, a.Decimals)
+	}
+	return a.Raw, nil
+}
+
+// Rescale converts the amount to other decimals, it fails if it loses precision
+func (a Amount) Rescale(decimals uint8) (Amount, error) {
+	if decimals > MaxAmountDecimals || a.Decimals > MaxAmountDecimals {
+		return Amount{}, fmt.Errorf("%w, from: %v, to: %v", ErrAmountInvalidDecimals, a.Decimals, decimals)
+	}
+	if decimals >= a.Decimals {
+		hi, lo := bits.Mul64(a.Raw, pow10[decimals-a.Decimals])
+		if hi != 0 {
+			return Amount{}, fmt.Errorf("%w, amount: %v, decimals: %v", ErrAmountOverflow, a, decimals)
+		}
+		return Amount{Raw: lo, Decimals: decimals}, nil
+	}
+	p := pow10[a.Decimals-decimals]
+	if a.Raw%p != 0 {
+		return Amount{}, fmt.Errorf("%w, amount: %v, decimals: %v", ErrAmountTooPrecise, a, decimals)
+	}
+	return Amount{Raw: a.Raw / p, Decimals: decimals}, nil
+}
+
+// Add returns a+b, both must have the same decimals
+func (a Amount) Add(b Amount) (Amount, error) {
+	if a.Decimals != b.Decimals {
+		return Amount{}, fmt.Errorf("%w, %v and %v", ErrAmountDecimalsMismatch, a.Decimals, b.Decimals)
+	}
+	sum, carry := bits.Add64(a.Raw, b.Raw, 0)
+	if carry != 0 {
+		return Amount{}, fmt.Errorf("%w, %v + %v", ErrAmountOverflow, a, b)
+	}
+	return Amount{Raw: sum, Decimals: a.Decimals}, nil
+}
+
+// Sub returns a-b, both must have the same decimals
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if a.Decimals != b.Decimals {
+		return Amount{}, fmt.Errorf("%w, %v and %v", ErrAmountDecimalsMismatch, a.Decimals, b.Decimals)
+	}
+	diff, borrow := bits.Sub64(a.Raw, b.Raw, 0)
+	if borrow != 0 {
+		return Amount{}, fmt.Errorf("%w, %v - %v", ErrAmountUnderflow, a, b)
+	}
+	return Amount{Raw: diff, Decimals: a.Decimals}, nil
+}
+
+// Mul returns a*n
+func (a Amount) Mul(n uint64) (Amount, error) {
+	hi, lo := bits.Mul64(a.Raw, n)
+	if hi != 0 {
+		return Amount{}, fmt.Errorf("%w, %v * %v", ErrAmountOverflow, a, n)
+	}
+	return Amount{Raw: lo, Decimals: a.Decimals}, nil
+}
+
+// Cmp compares the values, amounts with different decimals are compared exactly
+func (a Amount) Cmp(b Amount) int {
+	if a.Decimals > MaxAmountDecimals || b.Decimals > MaxAmountDecimals {
+		return a.bigCmp(b)
+	}
+	// scaling to the larger decimals in 128 bits never overflows since 10^19 < 2^64
+	aHi, aLo, bHi, bLo := uint64(0), a.Raw, uint64(0), b.Raw
+	if a.Decimals < b.Decimals {
+		aHi, aLo = bits.Mul64(a.Raw, pow10[b.Decimals-a.Decimals])
+	} else if b.Decimals < a.Decimals {
+		bHi, bLo = bits.Mul64(b.Raw, pow10[a.Decimals-b.Decimals])
+	}
+	switch {
+	case aHi < bHi || (aHi == bHi && aLo < bLo):
+		return -1
+	case aHi > bHi || (aHi == bHi && aLo > bLo):
+		return 1
+	}
+	return 0
+}
+
+// bigCmp compares amounts whose decimals are too large to scale in 128 bits
+func (a Amount) bigCmp(b Amount) int {
+	x, y := new(big.Int).SetUint64(a.Raw), new(big.Int).SetUint64(b.Raw)
+	if a.Decimals < b.Decimals {
+		x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(b.Decimals-a.Decimals)), nil))
+	} else if b.Decimals < a.Decimals {
+		y.Mul(y, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.Decimals-b.Decimals)), nil))
+	}
+	return x.Cmp(y)
+}
+
+// MarshalJSON encodes the amount as a string with all decimals, e.g. "1.500000000",
+// so the decimals survive a round trip
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.FixedString())
+}
+
+// UnmarshalJSON decodes a string amount, the decimals are always the digits of the
+// fraction whatever the amount held before, e.g. "1.50" has 2 decimals. use Rescale
+// to get the decimals of a mint.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	var decimals uint8
+	if _, fraction, ok := strings.Cut(s, "."); ok {
+		if len(fraction) > MaxAmountDecimals {
+			return fmt.Errorf("%w, amount: %v", ErrAmountTooPrecise, s)
+		}
+		decimals = uint8(len(fraction))
+	}
+	amount, err := ParseAmount(s, decimals)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		s        string
+		decimals uint8
+		want     Amount
+		wantErr  error
+	}{
+		{s: "1.5", decimals: 9, want: Amount{Raw: 1_500_000_000, Decimals: 9}},
+		{s: "0.000000001", decimals: 9, want: Amount{Raw: 1, Decimals: 9}},
+		{s: ".25", decimals: 2, want: Amount{Raw: 25, Decimals: 2}},
+		{s: "007", decimals: 0, want: Amount{Raw: 7, Decimals: 0}},
+		// trailing zeros are not precision
+		{s: "1.2300", decimals: 2, want: Amount{Raw: 123, Decimals: 2}},
+		{s: "18446744073709551615", decimals: 0, want: Amount{Raw: math.MaxUint64, Decimals: 0}},
+		{s: "18446744073.709551615", decimals: 9, want: Amount{Raw: math.MaxUint64, Decimals: 9}},
+		{s: "0.1", decimals: 19, want: Amount{Raw: 1_000_000_000_000_000_000, Decimals: 19}},
+		{s: "1.234", decimals: 2, wantErr: ErrAmountTooPrecise},
+		{s: "18446744073709551616", decimals: 0, wantErr: ErrAmountOverflow},
+		{s: "18446744073.709551616", decimals: 9, wantErr: ErrAmountOverflow},
+		{s: "18446744074", decimals: 9, wantErr: ErrAmountOverflow},
+		{s: "", decimals: 9, wantErr: ErrAmountInvalid},
+		{s: ".", decimals: 9, wantErr: ErrAmountInvalid},
+		{s: "1.", decimals: 9, wantErr: ErrAmountInvalid},
+		{s: "-1", decimals: 9, wantErr: ErrAmountInvalid},
+		{s: "1e9", decimals: 9, wantErr: ErrAmountInvalid},
+		{s: "1.2.3", decimals: 9, wantErr: ErrAmountInvalid},
+		{s: "1", decimals: 20, wantErr: ErrAmountInvalidDecimals},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseAmount(tt.s, tt.decimals)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		want   string
+		fixed  string
+	}{
+		{amount: Amount{Raw: 1_500_000_000, Decimals: 9}, want: "1.5", fixed: "1.500000000"},
+		{amount: Amount{Raw: 1, Decimals: 9}, want: "0.000000001", fixed: "0.000000001"},
+		{amount: Amount{Raw: 0, Decimals: 9}, want: "0", fixed: "0.000000000"},
+		{amount: Amount{Raw: 100, Decimals: 0}, want: "100", fixed: "100"},
+		{amount: Amount{Raw: 100, Decimals: 2}, want: "1", fixed: "1.00"},
+		{amount: Amount{Raw: math.MaxUint64, Decimals: 19}, want: "1.8446744073709551615", fixed: "1.8446744073709551615"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.amount.String())
+		assert.Equal(t, tt.fixed, tt.amount.FixedString())
+	}
+}
+
+func TestAmount_SOL(t *testing.T) {
+	a, err := ParseSOL("0.05")
+	assert.Nil(t, err)
+	lamports, err := a.Lamports()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(50_000_000), lamports)
+	assert.Equal(t, "0.05", Lamports(50_000_000).String())
+
+	_, err = NewAmount(1_000_000, 6).Lamports()
+	assert.ErrorIs(t, err, ErrAmountDecimalsMismatch)
+}
+
+func TestAmount_Math(t *testing.T) {
+	a := NewAmount(math.MaxUint64-1, 6)
+
+	got, err := a.Add(NewAmount(1, 6))
+	assert.Nil(t, err)
+	assert.Equal(t, NewAmount(math.MaxUint64, 6), got)
+	_, err = got.Add(NewAmount(1, 6))
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = a.Add(NewAmount(1, 9))
+	assert.ErrorIs(t, err, ErrAmountDecimalsMismatch)
+
+	got, err = NewAmount(5, 6).Sub(NewAmount(5, 6))
+	assert.Nil(t, err)
+	assert.True(t, got.IsZero())
+	_, err = NewAmount(5, 6).Sub(NewAmount(6, 6))
+	assert.ErrorIs(t, err, ErrAmountUnderflow)
+
+	got, err = NewAmount(1_500_000, 6).Mul(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "4.5", got.String())
+	_, err = a.Mul(2)
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+
+	got, err = NewAmount(1_500_000, 6).Rescale(9)
+	assert.Nil(t, err)
+	assert.Equal(t, NewAmount(1_500_000_000, 9), got)
+	got, err = got.Rescale(1)
+	assert.Nil(t, err)
+	assert.Equal(t, NewAmount(15, 1), got)
+	_, err = got.Rescale(0)
+	assert.ErrorIs(t, err, ErrAmountTooPrecise)
+	_, err = a.Rescale(9)
+	assert.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = NewAmount(1, 20).Rescale(9)
+	assert.EqualError(t, err, "invalid amount decimals, from: 20, to: 9")
+	_, err = NewAmount(1, 9).Rescale(20)
+	assert.EqualError(t, err, "invalid amount decimals, from: 9, to: 20")
+
+	assert.Equal(t, 0, NewAmount(15, 1).Cmp(NewAmount(1_500_000_000, 9)))
+	assert.Equal(t, -1, NewAmount(15, 1).Cmp(NewAmount(1_500_000_001, 9)))
+	assert.Equal(t, 1, NewAmount(math.MaxUint64, 0).Cmp(NewAmount(math.MaxUint64, 19)))
+
+	// decimals beyond MaxAmountDecimals are still compared exactly
+	assert.Equal(t, -1, NewAmount(1, 20).Cmp(NewAmount(1, 0)))
+	assert.Equal(t, 1, NewAmount(1, 0).Cmp(NewAmount(1, 20)))
+	assert.Equal(t, 0, NewAmount(100, 22).Cmp(NewAmount(1, 20)))
+	assert.Equal(t, 1, NewAmount(math.MaxUint64, 19).Cmp(NewAmount(math.MaxUint64, 255)))
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type A struct {
+		Amount Amount `json:"amount"`
+	}
+
+	b, err := json.Marshal(A{Amount: NewAmount(1_500_000_000, 9)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"amount":"1.500000000"}`, string(b))
+
+	var a A
+	assert.Nil(t, json.Unmarshal(b, &a))
+	assert.Equal(t, NewAmount(1_500_000_000, 9), a.Amount)
+
+	// the decimals come from the string, not from what the target held
+	for _, decimals := range []uint8{0, 2, 6} {
+		a = A{Amount: Amount{Decimals: decimals}}
+		assert.Nil(t, json.Unmarshal([]byte(`{"amount":"2.5"}`), &a))
+		assert.Equal(t, NewAmount(25, 1), a.Amount)
+	}
+	a = A{Amount: Amount{Raw: 7, Decimals: 9}}
+	assert.Nil(t, json.Unmarshal([]byte(`{"amount":"3"}`), &a))
+	assert.Equal(t, NewAmount(3, 0), a.Amount)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"0.12345678901234567890"}`), &a), ErrAmountTooPrecise)
+
+	a = A{}
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"abc"}`), &a), ErrAmountInvalid)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"amount":1.5}`), &a))
+}
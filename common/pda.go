@@ -0,0 +1,216 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrProgramAddressNotFound = errors.New("unable to find a viable program address")
+	ErrProgramAddressMismatch = errors.New("address is not the canonical program address of the seeds")
+)
+
+// Seed is one seed of a program derived address
+type Seed []byte
+
+func SeedBytes(b []byte) Seed {
+	return Seed(b)
+}
+
+func SeedString(s string) Seed {
+	return Seed(s)
+}
+
+func SeedPublicKey(p PublicKey) Seed {
+	return Seed(p.Bytes())
+}
+
+func SeedUint8(n uint8) Seed {
+	return Seed{n}
+}
+
+func SeedUint16(n uint16) Seed {
+	return binary.LittleEndian.AppendUint16(nil, n)
+}
+
+func SeedUint32(n uint32) Seed {
+	return binary.LittleEndian.AppendUint32(nil, n)
+}
+
+func SeedUint64(n uint64) Seed {
+	return binary.LittleEndian.AppendUint64(nil, n)
+}
+
+// SeedBump is the bump seed, it must be the last seed
+func SeedBump(bump uint8) Seed {
+	return Seed{bump}
+}
+
+// Seeds converts typed seeds to the form FindProgramAddress and CreateProgramAddress take
+func Seeds(seeds ...Seed) [][]byte {
+	output := make([][]byte, 0, len(seeds))
+	for _, seed := range seeds {
+		output = append(output, seed)
+	}
+	return output
+}
+
+// findProgramAddress hashes the seeds into one buffer and only rewrites the bump byte
+// on each try
+func findProgramAddress(seeds [][]byte, programID PublicKey) (PublicKey, uint8, error) {
+	if err := validateProgramAddressSeeds(seeds); err != nil {
+		return PublicKey{}, 0, err
+	}
+	size := 1 + PublicKeyLength + len("ProgramDerivedAddress")
+	for _, seed := range seeds {
+		size += len(seed)
+	}
+
+	buf := make([]byte, 0, size)
+	for _, seed := range seeds {
+		buf = append(buf, seed...)
+	}
+	bumpIndex := len(buf)
+	buf = append(buf, 0)
+	buf = append(buf, programID[:]...)
+	buf = append(buf, "ProgramDerivedAddress"...)
+
+	// like try_find_program_address, bump 0 is never tried
+	for bump := 0xff; bump > 0; bump-- {
+		buf[bumpIndex] = byte(bump)
+		pubkey := PublicKey(sha256.Sum256(buf))
+		if !IsOnCurve(pubkey) {
+			return pubkey, uint8(bump), nil
+		}
+	}
+	return PublicKey{}, 0, ErrProgramAddressNotFound
+}
+
+// validateProgramAddressSeeds checks the seeds leave room for the bump
+func validateProgramAddressSeeds(seeds [][]byte) error {
+	// the bump takes a seed
+	if len(seeds) > MaxSeed-1 {
+		return errors.New("length of the seed is too long for address generation")
+	}
+	for _, seed := range seeds {
+		if len(seed) > MaxSeedLength {
+			return errors.New("length of the seed is too long for address generation")
+		}
+	}
+	return nil
+}
+
+type programAddress struct {
+	pubkey PublicKey
+	bump   uint8
+}
+
+// ProgramAddressCache memoizes canonical program addresses and is safe for concurrent
+// use. it keeps two generations, when the current one is full it becomes the previous
+// one and the oldest generation is dropped, so it holds at most 2*size entries.
+// helpers of this sdk like FindAssociatedTokenAddress don't cache, create a cache
+// with NewProgramAddressCache where memoization pays off. a nil cache doesn't cache.
+type ProgramAddressCache struct {
+	size int
+
+	mu       sync.RWMutex
+	current  map[string]programAddress
+	previous map[string]programAddress
+}
+
+// NewProgramAddressCache creates a cache, size is the number of entries per generation
+func NewProgramAddressCache(size int) *ProgramAddressCache {
+	if size <= 0 {
+		size = 1
+	}
+	return &ProgramAddressCache{
+		size:    size,
+		current: make(map[string]programAddress),
+	}
+}
+
+// FindProgramAddress is FindProgramAddress with memoization, errors are not cached
+func (c *ProgramAddressCache) FindProgramAddress(seeds [][]byte, programID PublicKey) (PublicKey, uint8, error) {
+	if c == nil {
+		return findProgramAddress(seeds, programID)
+	}
+	// the key is only unique for valid seeds, a seed can't be longer than a byte prefix
+	if err := validateProgramAddressSeeds(seeds); err != nil {
+		return PublicKey{}, 0, err
+	}
+	key := programAddressCacheKey(seeds, programID)
+
+	c.mu.RLock()
+	v, ok := c.current[key]
+	if !ok {
+		v, ok = c.previous[key]
+	}
+	c.mu.RUnlock()
+	if ok {
+		return v.pubkey, v.bump, nil
+	}
+
+	pubkey, bump, err := findProgramAddress(seeds, programID)
+	if err != nil {
+		return PublicKey{}, 0, err
+	}
+
+	c.mu.Lock()
+	if len(c.current) >= c.size {
+		c.previous = c.current
+		c.current = make(map[string]programAddress)
+	}
+	c.current[key] = programAddress{pubkey: pubkey, bump: bump}
+	c.mu.Unlock()
+
+	return pubkey, bump, nil
+}
+
+// VerifyProgramAddress checks the address is the canonical program address of the
+// seeds and returns its bump
+func (c *ProgramAddressCache) VerifyProgramAddress(address PublicKey, seeds [][]byte, programID PublicKey) (uint8, error) {
+	pubkey, bump, err := c.FindProgramAddress(seeds, programID)
+	if err != nil {
+		return 0, err
+	}
+	if pubkey != address {
+		return 0, fmt.Errorf("%w, expected: %v, got: %v", ErrProgramAddressMismatch, pubkey, address)
+	}
+	return bump, nil
+}
+
+// Len returns the number of cached entries
+func (c *ProgramAddressCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.current) + len(c.previous)
+}
+
+// programAddressCacheKey length prefixes each seed so different splits of the same
+// bytes don't collide
+func programAddressCacheKey(seeds [][]byte, programID PublicKey) string {
+	size := PublicKeyLength
+	for _, seed := range seeds {
+		size += 1 + len(seed)
+	}
+	b := make([]byte, 0, size)
+	b = append(b, programID[:]...)
+	for _, seed := range seeds {
+		b = append(b, byte(len(seed)))
+		b = append(b, seed...)
+	}
+	return string(b)
+}
+
+// VerifyProgramAddress checks the address is the canonical program address of the
+// seeds and returns its bump, it doesn't cache
+func VerifyProgramAddress(address PublicKey, seeds [][]byte, programID PublicKey) (uint8, error) {
+	var c *ProgramAddressCache
+	return c.VerifyProgramAddress(address, seeds, programID)
+}
@@ -0,0 +1,176 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeeds(t *testing.T) {
+	assert.Equal(t,
+		[][]byte{
+			[]byte("metadata"),
+			SystemProgramID.Bytes(),
+			{1},
+			{2, 1},
+			{3, 2, 1, 0},
+			{4, 3, 2, 1, 0, 0, 0, 0},
+			{5},
+			{6, 7},
+		},
+		Seeds(
+			SeedString("metadata"),
+			SeedPublicKey(SystemProgramID),
+			SeedUint8(1),
+			SeedUint16(0x0102),
+			SeedUint32(0x010203),
+			SeedUint64(0x01020304),
+			SeedBump(5),
+			SeedBytes([]byte{6, 7}),
+		),
+	)
+}
+
+func TestFindProgramAddress_Seeds(t *testing.T) {
+	seeds := Seeds(
+		SeedPublicKey(PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+		SeedPublicKey(TokenProgramID),
+		SeedPublicKey(PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")),
+	)
+	pubkey, bump, err := FindProgramAddress(seeds, SPLAssociatedTokenAccountProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, PublicKeyFromString("HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1"), pubkey)
+	assert.Equal(t, uint8(254), bump)
+
+	// it must be the same as CreateProgramAddress with the bump
+	created, err := CreateProgramAddress(append(seeds, SeedBump(bump)), SPLAssociatedTokenAccountProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, pubkey, created)
+
+	_, _, err = FindProgramAddress(Seeds(make([]Seed, MaxSeed)...), SystemProgramID)
+	assert.EqualError(t, err, "length of the seed is too long for address generation")
+	_, _, err = FindProgramAddress(Seeds(SeedBytes(make([]byte, MaxSeedLength+1))), SystemProgramID)
+	assert.EqualError(t, err, "length of the seed is too long for address generation")
+}
+
+func TestProgramAddressCache(t *testing.T) {
+	cache := NewProgramAddressCache(4)
+	wallet := PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	mint := PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	seeds := Seeds(SeedPublicKey(wallet), SeedPublicKey(TokenProgramID), SeedPublicKey(mint))
+
+	pubkey, bump, err := cache.FindProgramAddress(seeds, SPLAssociatedTokenAccountProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, PublicKeyFromString("HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1"), pubkey)
+	assert.Equal(t, uint8(254), bump)
+	assert.Equal(t, 1, cache.Len())
+
+	// a hit doesn't add an entry
+	_, _, err = cache.FindProgramAddress(seeds, SPLAssociatedTokenAccountProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cache.Len())
+
+	// the same bytes split differently derive the same address but are other entries
+	joined, _, err := cache.FindProgramAddress(Seeds(SeedBytes([]byte{1, 2})), SystemProgramID)
+	assert.Nil(t, err)
+	split, _, err := cache.FindProgramAddress(Seeds(SeedBytes([]byte{1}), SeedBytes([]byte{2})), SystemProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, joined, split)
+	assert.Equal(t, 3, cache.Len())
+
+	// errors are not cached
+	_, _, err = cache.FindProgramAddress(Seeds(SeedBytes(make([]byte, MaxSeedLength+1))), SystemProgramID)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, cache.Len())
+
+	// a 256 bytes seed wraps its length prefix to 0, it must not hit the entry of
+	// valid seeds which have the same key
+	valid := []Seed{SeedBytes(nil)}
+	var long []byte
+	for i := 0; i < 7; i++ {
+		valid = append(valid, SeedBytes(bytes.Repeat([]byte{0xaa}, 32)))
+		long = append(append(long, 32), bytes.Repeat([]byte{0xaa}, 32)...)
+	}
+	valid = append(valid, SeedBytes(bytes.Repeat([]byte{0xbb}, 24)))
+	long = append(append(long, 24), bytes.Repeat([]byte{0xbb}, 24)...)
+	_, _, err = cache.FindProgramAddress(Seeds(valid...), SystemProgramID)
+	assert.Nil(t, err)
+	_, _, err = cache.FindProgramAddress(Seeds(SeedBytes(long)), SystemProgramID)
+	assert.EqualError(t, err, "length of the seed is too long for address generation")
+	_, _, err = cache.FindProgramAddress(Seeds(make([]Seed, MaxSeed)...), SystemProgramID)
+	assert.EqualError(t, err, "length of the seed is too long for address generation")
+
+	// a full generation is rotated, it holds at most 2*size entries
+	for i := uint64(0); i < 10; i++ {
+		_, _, err = cache.FindProgramAddress(Seeds(SeedUint64(i)), SystemProgramID)
+		assert.Nil(t, err)
+	}
+	assert.LessOrEqual(t, cache.Len(), 8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				seeds := Seeds(SeedString(fmt.Sprintf("%v", j%5)))
+				got, gotBump, err := cache.FindProgramAddress(seeds, SystemProgramID)
+				want, wantBump, _ := FindProgramAddress(seeds, SystemProgramID)
+				assert.Nil(t, err)
+				assert.Equal(t, want, got)
+				assert.Equal(t, wantBump, gotBump)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestProgramAddressCache_Nil(t *testing.T) {
+	var cache *ProgramAddressCache
+	seeds := Seeds(SeedString("seed"))
+	want, wantBump, err := FindProgramAddress(seeds, SystemProgramID)
+	assert.Nil(t, err)
+
+	got, gotBump, err := cache.FindProgramAddress(seeds, SystemProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, wantBump, gotBump)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestVerifyProgramAddress(t *testing.T) {
+	seeds := Seeds(
+		SeedPublicKey(PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+		SeedPublicKey(TokenProgramID),
+		SeedPublicKey(PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")),
+	)
+
+	bump, err := VerifyProgramAddress(PublicKeyFromString("HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1"), seeds, SPLAssociatedTokenAccountProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(254), bump)
+
+	// a valid program address with a non canonical bump
+	for b := 253; b >= 0; b-- {
+		nonCanonical, err := CreateProgramAddress(append(seeds, SeedBump(uint8(b))), SPLAssociatedTokenAccountProgramID)
+		if err != nil {
+			continue
+		}
+		_, err = VerifyProgramAddress(nonCanonical, seeds, SPLAssociatedTokenAccountProgramID)
+		assert.ErrorIs(t, err, ErrProgramAddressMismatch)
+		break
+	}
+
+	_, err = VerifyProgramAddress(SystemProgramID, seeds, SPLAssociatedTokenAccountProgramID)
+	assert.EqualError(t, err, "address is not the canonical program address of the seeds, expected: HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1, got: 11111111111111111111111111111111")
+}
+
+func BenchmarkFindAssociatedTokenAddress(b *testing.B) {
+	wallet := PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	mint := PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	for i := 0; i < b.N; i++ {
+		_, _, _ = FindAssociatedTokenAddress(wallet, mint)
+	}
+}
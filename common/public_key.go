@@ -2,6 +2,7 @@ package common
 
 import (
 	"crypto/sha256"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,12 +17,36 @@ const (
 	MaxSeed         = 16
 )
 
+var (
+	ErrPublicKeyInvalidBase58 = errors.New("invalid base58 public key")
+	ErrPublicKeyInvalidLength = errors.New("invalid public key length")
+)
+
 type PublicKey [PublicKeyLength]byte
 
 func (p PublicKey) String() string {
 	return p.ToBase58()
 }
 
+// ParsePublicKey parses a base58 public key which must be exactly 32 bytes
+func ParsePublicKey(s string) (PublicKey, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("%w, err: %v", ErrPublicKeyInvalidBase58, err)
+	}
+	return ParsePublicKeyBytes(b)
+}
+
+// ParsePublicKeyBytes requires exactly 32 bytes
+func ParsePublicKeyBytes(b []byte) (PublicKey, error) {
+	if len(b) != PublicKeyLength {
+		return PublicKey{}, fmt.Errorf("%w, expected: %v, got: %v", ErrPublicKeyInvalidLength, PublicKeyLength, len(b))
+	}
+	var pubkey PublicKey
+	copy(pubkey[:], b)
+	return pubkey, nil
+}
+
 func PublicKeyFromString(s string) PublicKey {
 	d, _ := base58.Decode(s)
 	return PublicKeyFromBytes(d)
@@ -91,6 +116,49 @@ func (p *PublicKey) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (p PublicKey) MarshalText() ([]byte, error) {
+	return []byte(p.ToBase58()), nil
+}
+
+func (p *PublicKey) UnmarshalText(text []byte) error {
+	pubkey, err := ParsePublicKey(string(text))
+	if err != nil {
+		return err
+	}
+	*p = pubkey
+	return nil
+}
+
+// Value stores the public key as base58 text
+func (p PublicKey) Value() (driver.Value, error) {
+	return p.ToBase58(), nil
+}
+
+// Scan reads a base58 text column, NULL becomes the zero public key
+func (p *PublicKey) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*p = PublicKey{}
+		return nil
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	}
+	return fmt.Errorf("failed to scan %T into public key", src)
+}
+
+// IsZero reports whether the public key is all zeros, e.g. unset
+func (p PublicKey) IsZero() bool {
+	return p == PublicKey{}
+}
+
+// IsOnCurve reports whether the public key is an ed25519 point, a program derived
+// address is off the curve
+func (p PublicKey) IsOnCurve() bool {
+	return IsOnCurve(p)
+}
+
 func IsOnCurve(p PublicKey) bool {
 	_, err := new(edwards25519.Point).SetBytes(p.Bytes())
 	return err == nil
@@ -106,24 +174,18 @@ func CreateWithSeed(from PublicKey, seed string, programID PublicKey) PublicKey
 }
 
 func FindAssociatedTokenAddress(walletAddress, tokenMintAddress PublicKey) (PublicKey, uint8, error) {
-	seeds := [][]byte{}
-	seeds = append(seeds, walletAddress.Bytes())
-	seeds = append(seeds, TokenProgramID.Bytes())
-	seeds = append(seeds, tokenMintAddress.Bytes())
-
-	return FindProgramAddress(seeds, SPLAssociatedTokenAccountProgramID)
+	return FindProgramAddress(
+		Seeds(
+			SeedPublicKey(walletAddress),
+			SeedPublicKey(TokenProgramID),
+			SeedPublicKey(tokenMintAddress),
+		),
+		SPLAssociatedTokenAccountProgramID,
+	)
 }
 
+// FindProgramAddress returns the canonical program address, the first one off the
+// curve when the bump goes down from 255
 func FindProgramAddress(seed [][]byte, programID PublicKey) (PublicKey, uint8, error) {
-	var pubKey PublicKey
-	var err error
-	var nonce uint8 = 0xff
-	for nonce != 0x0 {
-		pubKey, err = CreateProgramAddress(append(seed, []byte{byte(nonce)}), programID)
-		if err == nil {
-			return pubKey, nonce, nil
-		}
-		nonce--
-	}
-	return PublicKey{}, nonce, errors.New("unable to find a viable program address")
+	return findProgramAddress(seed, programID)
 }
@@ -268,3 +268,66 @@ func TestPublicKey_JSON(t *testing.T) {
 	err = json.Unmarshal([]byte(`{"pubkey":"EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx123"}`), &a4)
 	assert.Equal(t, err, errors.New("a valid pubkey should be a 32-byte array. got: 34"))
 }
+
+func TestParsePublicKey(t *testing.T) {
+	got, err := ParsePublicKey("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	assert.Nil(t, err)
+	assert.Equal(t, PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), got)
+
+	_, err = ParsePublicKey("0")
+	assert.ErrorIs(t, err, ErrPublicKeyInvalidBase58)
+
+	// PublicKeyFromString truncates it silently
+	_, err = ParsePublicKey("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx123")
+	assert.ErrorIs(t, err, ErrPublicKeyInvalidLength)
+	assert.EqualError(t, err, "invalid public key length, expected: 32, got: 34")
+
+	_, err = ParsePublicKeyBytes([]byte{1, 2, 3})
+	assert.ErrorIs(t, err, ErrPublicKeyInvalidLength)
+}
+
+func TestPublicKey_Text(t *testing.T) {
+	p := PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	// text marshaler makes it a valid json map key
+	b, err := json.Marshal(map[PublicKey]uint64{p: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7":1}`, string(b))
+
+	var m map[PublicKey]uint64
+	assert.Nil(t, json.Unmarshal(b, &m))
+	assert.Equal(t, map[PublicKey]uint64{p: 1}, m)
+
+	var got PublicKey
+	assert.ErrorIs(t, got.UnmarshalText([]byte("1111")), ErrPublicKeyInvalidLength)
+}
+
+func TestPublicKey_SQL(t *testing.T) {
+	p := PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	v, err := p.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7", v)
+
+	var got PublicKey
+	assert.Nil(t, got.Scan("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"))
+	assert.Equal(t, p, got)
+
+	got = PublicKey{}
+	assert.Nil(t, got.Scan([]byte("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")))
+	assert.Equal(t, p, got)
+
+	assert.Nil(t, got.Scan(nil))
+	assert.True(t, got.IsZero())
+
+	assert.EqualError(t, got.Scan(1), "failed to scan int into public key")
+	assert.ErrorIs(t, got.Scan("0"), ErrPublicKeyInvalidBase58)
+}
+
+func TestPublicKey_IsZero(t *testing.T) {
+	assert.True(t, PublicKey{}.IsZero())
+	assert.True(t, SystemProgramID.IsZero())
+	assert.False(t, TokenProgramID.IsZero())
+	assert.True(t, PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7").IsOnCurve())
+	assert.False(t, PublicKeyFromString("HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1").IsOnCurve())
+}
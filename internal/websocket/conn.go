@@ -0,0 +1,355 @@
+// Package websocket is a minimal RFC 6455 implementation which only covers what
+// the pubsub client needs: text frames, fragmentation, ping/pong and close.
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+const (
+	opContinuation = 0
+	acceptGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+// MaxMessageSize is the upper bound of a single (reassembled) message
+const MaxMessageSize = 64 << 20
+
+var (
+	ErrClosed          = errors.New("websocket: connection closed")
+	ErrMessageTooLarge = errors.New("websocket: message too large")
+	ErrProtocol        = errors.New("websocket: protocol error")
+)
+
+type Conn struct {
+	conn     net.Conn
+	br       *bufio.Reader
+	isClient bool
+
+	writeMu sync.Mutex
+	// lastRead is the unix nano time the last frame was read, pong frames included
+	lastRead atomic.Int64
+}
+
+// Dial opens a client connection to a ws:// or wss:// url
+func Dial(ctx context.Context, rawURL string, header http.Header) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url, err: %v", err)
+	}
+
+	var useTLS bool
+	switch u.Scheme {
+	case "ws", "http":
+		u.Scheme = "http"
+	case "wss", "https":
+		u.Scheme = "https"
+		useTLS = true
+	default:
+		return nil, fmt.Errorf("unsupported scheme: %v", u.Scheme)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		if useTLS {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	var netConn net.Conn
+	if useTLS {
+		d := tls.Dialer{Config: &tls.Config{ServerName: u.Hostname()}}
+		netConn, err = d.DialContext(ctx, "tcp", host)
+	} else {
+		var d net.Dialer
+		netConn, err = d.DialContext(ctx, "tcp", host)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial, err: %v", err)
+	}
+
+	// the handshake should also respect the context deadline
+	handshakeDone := make(chan struct{})
+	defer close(handshakeDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			netConn.Close()
+		case <-handshakeDone:
+		}
+	}()
+
+	nonce := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to generate key, err: %v", err)
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        u,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Host:       u.Host,
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := req.Write(netConn); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to write handshake, err: %v", err)
+	}
+
+	br := bufio.NewReader(netConn)
+	res, err := http.ReadResponse(br, req)
+	if err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to read handshake, err: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		netConn.Close()
+		return nil, fmt.Errorf("unexpected handshake status code: %v", res.StatusCode)
+	}
+	if res.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		netConn.Close()
+		return nil, fmt.Errorf("%w, invalid Sec-WebSocket-Accept", ErrProtocol)
+	}
+
+	return &Conn{conn: netConn, br: br, isClient: true}, nil
+}
+
+// Accept upgrades a server side http request to a websocket connection
+func Accept(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "not a websocket handshake", http.StatusBadRequest)
+		return nil, fmt.Errorf("%w, missing upgrade header", ErrProtocol)
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, fmt.Errorf("%w, missing Sec-WebSocket-Key", ErrProtocol)
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("response writer does not support hijacking")
+	}
+	netConn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hijack, err: %v", err)
+	}
+
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := netConn.Write([]byte(handshake)); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to write handshake, err: %v", err)
+	}
+
+	return &Conn{conn: netConn, br: rw.Reader, isClient: false}, nil
+}
+
+// ReadMessage returns the next data message. ping frames are answered
+// automatically and a close frame is reported as ErrClosed.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var (
+		messageType int
+		message     []byte
+	)
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.WriteMessage(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			_ = c.WriteMessage(CloseMessage, payload)
+			return 0, nil, ErrClosed
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, fmt.Errorf("%w, unexpected new message in fragmented message", ErrProtocol)
+			}
+			messageType = opcode
+		case opContinuation:
+			if messageType == 0 {
+				return 0, nil, fmt.Errorf("%w, unexpected continuation frame", ErrProtocol)
+			}
+		default:
+			return 0, nil, fmt.Errorf("%w, unknown opcode %v", ErrProtocol, opcode)
+		}
+
+		if len(message)+len(payload) > MaxMessageSize {
+			return 0, nil, ErrMessageTooLarge
+		}
+		message = append(message, payload...)
+		if fin {
+			return messageType, message, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (bool, int, []byte, error) {
+	var h [2]byte
+	if _, err := io.ReadFull(c.br, h[:]); err != nil {
+		return false, 0, nil, err
+	}
+	c.lastRead.Store(time.Now().UnixNano())
+	fin := h[0]&0x80 != 0
+	opcode := int(h[0] & 0x0f)
+	masked := h[1]&0x80 != 0
+
+	length := uint64(h[1] & 0x7f)
+	switch length {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(c.br, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(c.br, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(b[:])
+	}
+	if length > MaxMessageSize {
+		return false, 0, nil, ErrMessageTooLarge
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return fin, opcode, payload, nil
+}
+
+// LastRead returns when the last frame arrived, it's zero if nothing has been read
+func (c *Conn) LastRead() time.Time {
+	if v := c.lastRead.Load(); v > 0 {
+		return time.Unix(0, v)
+	}
+	return time.Time{}
+}
+
+// WriteMessage sends data as a single frame. it is safe for concurrent use.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	frame := make([]byte, 0, 14+len(data))
+	frame = append(frame, 0x80|byte(messageType))
+
+	var maskBit byte
+	if c.isClient {
+		maskBit = 0x80
+	}
+	switch l := len(data); {
+	case l < 126:
+		frame = append(frame, maskBit|byte(l))
+	case l <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(l))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(l))
+	}
+
+	if c.isClient {
+		var mask [4]byte
+		if _, err := io.ReadFull(rand.Reader, mask[:]); err != nil {
+			return fmt.Errorf("failed to generate mask, err: %v", err)
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, data...)
+		maskBytes(mask, frame[start:])
+	} else {
+		frame = append(frame, data...)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close closes the underlying connection without waiting for the peer
+func (c *Conn) Close() error {
+	_ = c.WriteMessage(CloseMessage, []byte{0x03, 0xe8}) // 1000, normal closure
+	return c.conn.Close()
+}
+
+func maskBytes(mask [4]byte, b []byte) {
+	for i := range b {
+		b[i] ^= mask[i%4]
+	}
+}
+
+func acceptKey(key string) string {
+	h := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func headerContains(h http.Header, name, value string) bool {
+	for _, v := range h.Values(name) {
+		for _, s := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(s), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,301 @@
+package bincode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+var (
+	ErrShortData       = errors.New("unexpected end of data")
+	ErrInvalidBool     = errors.New("invalid bool")
+	ErrInvalidOption   = errors.New("invalid option tag")
+	ErrInvalidString   = errors.New("invalid utf-8 string")
+	ErrUnknownVariant  = errors.New("unknown enum variant")
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// DecodeError tells where the decoding failed, Path is the field path like
+// TokenAccount.Delegate and Offset is the byte offset of the field
+type DecodeError struct {
+	Path   string
+	Offset int
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("bincode: failed to decode %v at offset %v, err: %v", e.Path, e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DeserializeData decodes data into v, a non-nil pointer, the same layout SerializeData
+// encodes. trailing bytes are ignored since accounts can be larger than their state.
+//
+// supported types are bool, all sized ints, Uint128, Int128, fixed arrays, u64 length
+// prefixed slices and strings, structs and pointers as Option. struct tags:
+//
+//	`bincode:"-"`         skips the field
+//	`bincode:"coption"`   a pointer encoded as COption, a u32 tag followed by the value
+//	                      which is zeros when it is None
+//	`bincode:"enum"`      the discriminant of a tagged enum, an unsigned int field
+//	`bincode:"variant=N"` the field is only encoded when the discriminant is N, use a
+//	                      struct{} field for a variant without data
+func DeserializeData(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("%w, expected a non-nil pointer, got: %T", ErrUnsupportedType, v)
+	}
+	d := decoder{data: data}
+	return d.decode(rv.Elem(), rv.Elem().Type().Name())
+}
+
+type decoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *decoder) errorf(path string, offset int, err error) error {
+	return &DecodeError{Path: path, Offset: offset, Err: err}
+}
+
+func (d *decoder) read(n int, path string) ([]byte, error) {
+	if n < 0 || n > len(d.data)-d.offset {
+		return nil, d.errorf(path, d.offset, fmt.Errorf("%w, need %v bytes, remaining: %v", ErrShortData, n, len(d.data)-d.offset))
+	}
+	b := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return b, nil
+}
+
+func (d *decoder) readLength(elem reflect.Type, path string) (int, error) {
+	offset := d.offset
+	b, err := d.read(8, path)
+	if err != nil {
+		return 0, err
+	}
+	l := binary.LittleEndian.Uint64(b)
+	// reject lengths the remaining data can't hold before allocating
+	remaining := uint64(len(d.data) - d.offset)
+	if size := minSize(elem); (size == 0 && l > remaining) || (size > 0 && l > remaining/uint64(size)) {
+		return 0, d.errorf(path, offset, fmt.Errorf("%w, length: %v, remaining: %v", ErrShortData, l, remaining))
+	}
+	return int(l), nil
+}
+
+func (d *decoder) decode(v reflect.Value, path string) error {
+	offset := d.offset
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := d.read(1, path)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			v.SetBool(false)
+		case 1:
+			v.SetBool(true)
+		default:
+			return d.errorf(path, offset, fmt.Errorf("%w: %v", ErrInvalidBool, b[0]))
+		}
+		return nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b, err := d.read(int(v.Type().Size()), path)
+		if err != nil {
+			return err
+		}
+		v.SetUint(readUint(b))
+		return nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b, err := d.read(int(v.Type().Size()), path)
+		if err != nil {
+			return err
+		}
+		u := readUint(b)
+		// sign extend
+		shift := 64 - 8*len(b)
+		v.SetInt(int64(u<<shift) >> shift)
+		return nil
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := d.read(v.Len(), path)
+			if err != nil {
+				return err
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := d.decode(v.Index(i), fmt.Sprintf("%v[%v]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		l, err := d.readLength(v.Type().Elem(), path)
+		if err != nil {
+			return err
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := d.read(l, path)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+		s := reflect.MakeSlice(v.Type(), l, l)
+		for i := 0; i < l; i++ {
+			if err := d.decode(s.Index(i), fmt.Sprintf("%v[%v]", path, i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.String:
+		l, err := d.readLength(reflect.TypeOf(byte(0)), path)
+		if err != nil {
+			return err
+		}
+		b, err := d.read(l, path)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return d.errorf(path, offset, ErrInvalidString)
+		}
+		v.SetString(string(b))
+		return nil
+	case reflect.Ptr:
+		b, err := d.read(1, path)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		case 1:
+			elem := reflect.New(v.Type().Elem())
+			if err := d.decode(elem.Elem(), path); err != nil {
+				return err
+			}
+			v.Set(elem)
+			return nil
+		}
+		return d.errorf(path, offset, fmt.Errorf("%w: %v", ErrInvalidOption, b[0]))
+	case reflect.Struct:
+		return d.decodeStruct(v, path)
+	}
+	return d.errorf(path, offset, fmt.Errorf("%w: %v", ErrUnsupportedType, v.Type()))
+}
+
+func (d *decoder) decodeStruct(v reflect.Value, path string) error {
+	t := v.Type()
+	tags, err := structTags(t)
+	if err != nil {
+		return d.errorf(path, d.offset, err)
+	}
+
+	var discriminant uint64
+	for i := 0; i < t.NumField(); i++ {
+		tag := tags[i]
+		field := v.Field(i)
+		fieldPath := path + "." + t.Field(i).Name
+		switch {
+		case tag.skip:
+			continue
+		case tag.hasVariant && tag.variant != discriminant:
+			field.Set(reflect.Zero(field.Type()))
+			continue
+		case tag.coption:
+			if err := d.decodeCOption(field, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		offset := d.offset
+		if err := d.decode(field, fieldPath); err != nil {
+			return err
+		}
+		if tag.enum {
+			discriminant = field.Uint()
+			if !tags.hasVariant(discriminant) {
+				return d.errorf(fieldPath, offset, fmt.Errorf("%w: %v", ErrUnknownVariant, discriminant))
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeCOption(v reflect.Value, path string) error {
+	offset := d.offset
+	b, err := d.read(4, path)
+	if err != nil {
+		return err
+	}
+	// the value is always there, zeros for None
+	elem := reflect.New(v.Type().Elem())
+	if err := d.decode(elem.Elem(), path); err != nil {
+		return err
+	}
+	switch binary.LittleEndian.Uint32(b) {
+	case 0:
+		v.Set(reflect.Zero(v.Type()))
+	case 1:
+		v.Set(elem)
+	default:
+		return d.errorf(path, offset, fmt.Errorf("%w: %v", ErrInvalidOption, binary.LittleEndian.Uint32(b)))
+	}
+	return nil
+}
+
+func readUint(b []byte) uint64 {
+	switch len(b) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(b))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// minSize is the least number of bytes a value of t takes
+func minSize(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(t.Size())
+	case reflect.Array:
+		return t.Len() * minSize(t.Elem())
+	case reflect.Slice, reflect.String:
+		return 8
+	case reflect.Ptr:
+		return 1
+	case reflect.Struct:
+		tags, err := structTags(t)
+		if err != nil {
+			return 0
+		}
+		size := 0
+		for i := 0; i < t.NumField(); i++ {
+			switch {
+			case tags[i].skip, tags[i].hasVariant:
+			case tags[i].coption:
+				size += 4 + minSize(t.Field(i).Type.Elem())
+			default:
+				size += minSize(t.Field(i).Type)
+			}
+		}
+		return size
+	}
+	return 0
+}
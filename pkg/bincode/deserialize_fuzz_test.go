@@ -0,0 +1,38 @@
+package bincode
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzDeserializeData(f *testing.F) {
+	u16 := uint16(1)
+	pubkey := common.TokenProgramID
+	seed, err := SerializeData(testData{
+		Bytes:   []byte{1, 2, 3},
+		Pubkeys: []common.PublicKey{pubkey},
+		Nested:  []testVariantA{{Amount: 1}},
+		String:  "hello",
+		Option:  &u16,
+		COption: &pubkey,
+		Enum:    testEnum{Type: 7, B: testVariantB{Name: "b"}},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v testData
+		if err := DeserializeData(data, &v); err != nil {
+			return
+		}
+		b, err := SerializeData(v)
+		assert.Nil(t, err)
+		var got testData
+		assert.Nil(t, DeserializeData(b, &got))
+		assert.Equal(t, v, got)
+	})
+}
@@ -0,0 +1,246 @@
+package bincode
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testVariantA struct {
+	Amount uint64
+}
+
+type testVariantB struct {
+	Name string
+}
+
+type testEnum struct {
+	Type  uint32       `bincode:"enum"`
+	Unit  struct{}     `bincode:"variant=0"`
+	A     testVariantA `bincode:"variant=1"`
+	B     testVariantB `bincode:"variant=7"`
+	After uint8
+}
+
+type testData struct {
+	Bool      bool
+	U8        uint8
+	I8        int8
+	U16       uint16
+	I16       int16
+	U32       uint32
+	I32       int32
+	U64       uint64
+	I64       int64
+	U128      Uint128
+	I128      Int128
+	Pubkey    common.PublicKey
+	Array     [2]uint16
+	Bytes     []byte
+	Pubkeys   []common.PublicKey
+	Nested    []testVariantA
+	String    string
+	Option    *uint16
+	None      *common.PublicKey
+	COption   *common.PublicKey `bincode:"coption"`
+	COptNone  *uint64           `bincode:"coption"`
+	Enum      testEnum
+	Skipped   uint64 `bincode:"-"`
+	Remaining uint8
+}
+
+func TestDeserializeData_RoundTrip(t *testing.T) {
+	u16 := uint16(0xbeef)
+	pubkey := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	want := testData{
+		Bool:      true,
+		U8:        math.MaxUint8,
+		I8:        math.MinInt8,
+		U16:       math.MaxUint16,
+		I16:       -2,
+		U32:       math.MaxUint32,
+		I32:       math.MinInt32,
+		U64:       math.MaxUint64,
+		I64:       -1,
+		U128:      Uint128{Lo: 1, Hi: 2},
+		I128:      NewInt128(-5),
+		Pubkey:    pubkey,
+		Array:     [2]uint16{1, 2},
+		Bytes:     []byte{1, 2, 3},
+		Pubkeys:   []common.PublicKey{pubkey, common.TokenProgramID},
+		Nested:    []testVariantA{{Amount: 1}, {Amount: 2}},
+		String:    "hello",
+		Option:    &u16,
+		None:      nil,
+		COption:   &pubkey,
+		COptNone:  nil,
+		Enum:      testEnum{Type: 7, B: testVariantB{Name: "b"}, After: 9},
+		Remaining: 3,
+	}
+
+	b, err := SerializeData(want)
+	assert.Nil(t, err)
+
+	var got testData
+	assert.Nil(t, DeserializeData(b, &got))
+	assert.Equal(t, want, got)
+
+	// trailing bytes are ignored
+	assert.Nil(t, DeserializeData(append(b, 0, 0), &got))
+	assert.Equal(t, want, got)
+}
+
+func TestDeserializeData_Enum(t *testing.T) {
+	tests := []struct {
+		name string
+		enum testEnum
+		data []byte
+	}{
+		{
+			name: "unit",
+			enum: testEnum{Type: 0, After: 1},
+			data: []byte{0, 0, 0, 0, 1},
+		},
+		{
+			name: "data",
+			enum: testEnum{Type: 1, A: testVariantA{Amount: 2}, After: 1},
+			data: []byte{1, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := SerializeData(tt.enum)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.data, b)
+
+			var got testEnum
+			assert.Nil(t, DeserializeData(tt.data, &got))
+			assert.Equal(t, tt.enum, got)
+		})
+	}
+
+	// other variants are not encoded
+	b, err := SerializeData(testEnum{Type: 0, A: testVariantA{Amount: 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0}, b)
+
+	_, err = SerializeData(testEnum{Type: 2})
+	assert.ErrorIs(t, err, ErrUnknownVariant)
+}
+
+func TestDeserializeData_COption(t *testing.T) {
+	type account struct {
+		Amount   uint64
+		Delegate *common.PublicKey `bincode:"coption"`
+	}
+	pubkey := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	// None takes the same space as Some
+	b, err := SerializeData(account{Amount: 1})
+	assert.Nil(t, err)
+	assert.Len(t, b, 8+4+32)
+	b, err = SerializeData(account{Amount: 1, Delegate: &pubkey})
+	assert.Nil(t, err)
+	assert.Len(t, b, 8+4+32)
+	assert.Equal(t, []byte{1, 0, 0, 0}, b[8:12])
+
+	var got account
+	assert.Nil(t, DeserializeData(b, &got))
+	assert.Equal(t, account{Amount: 1, Delegate: &pubkey}, got)
+}
+
+func TestDeserializeData_Error(t *testing.T) {
+	type account struct {
+		Mint     common.PublicKey
+		Amount   uint64
+		Delegate *common.PublicKey `bincode:"coption"`
+		Frozen   bool
+		Names    []string
+	}
+	valid, err := SerializeData(account{Names: []string{"a"}})
+	assert.Nil(t, err)
+
+	lengthOffset := 32 + 8 + 36 + 1
+	hugeLength := append([]byte{}, valid...)
+	binary.LittleEndian.PutUint64(hugeLength[lengthOffset:], math.MaxUint64)
+
+	invalidUTF8 := append([]byte{}, valid...)
+	invalidUTF8[len(invalidUTF8)-1] = 0xff
+
+	tests := []struct {
+		name    string
+		data    []byte
+		err     error
+		message string
+	}{
+		{
+			name:    "short",
+			data:    valid[:20],
+			err:     ErrShortData,
+			message: "bincode: failed to decode account.Mint at offset 0, err: unexpected end of data, need 32 bytes, remaining: 20",
+		},
+		{
+			name:    "short in the middle",
+			data:    valid[:45],
+			err:     ErrShortData,
+			message: "bincode: failed to decode account.Delegate at offset 44, err: unexpected end of data, need 32 bytes, remaining: 1",
+		},
+		{
+			name:    "invalid coption",
+			data:    append(append(append([]byte{}, valid[:40]...), 2, 0, 0, 0), valid[44:]...),
+			err:     ErrInvalidOption,
+			message: "bincode: failed to decode account.Delegate at offset 40, err: invalid option tag: 2",
+		},
+		{
+			name:    "invalid bool",
+			data:    append(append(append([]byte{}, valid[:76]...), 2), valid[77:]...),
+			err:     ErrInvalidBool,
+			message: "bincode: failed to decode account.Frozen at offset 76, err: invalid bool: 2",
+		},
+		{
+			name:    "huge length",
+			data:    hugeLength,
+			err:     ErrShortData,
+			message: "bincode: failed to decode account.Names at offset 77, err: unexpected end of data, length: 18446744073709551615, remaining: 9",
+		},
+		{
+			name:    "invalid utf-8",
+			data:    invalidUTF8,
+			err:     ErrInvalidString,
+			message: "bincode: failed to decode account.Names[0] at offset 85, err: invalid utf-8 string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got account
+			err := DeserializeData(tt.data, &got)
+			assert.ErrorIs(t, err, tt.err)
+			assert.EqualError(t, err, tt.message)
+
+			var decodeErr *DecodeError
+			assert.True(t, errors.As(err, &decodeErr))
+		})
+	}
+
+	var enum testEnum
+	err = DeserializeData([]byte{2, 0, 0, 0, 0}, &enum)
+	assert.EqualError(t, err, "bincode: failed to decode testEnum.Type at offset 0, err: unknown enum variant: 2")
+
+	assert.ErrorIs(t, DeserializeData(valid, account{}), ErrUnsupportedType)
+	var m map[string]string
+	assert.ErrorIs(t, DeserializeData(valid, &m), ErrUnsupportedType)
+}
+
+func TestInt128(t *testing.T) {
+	assert.Equal(t, "36893488147419103233", Uint128{Lo: 1, Hi: 2}.String())
+	assert.Equal(t, "-5", NewInt128(-5).String())
+	assert.Equal(t, "5", NewInt128(5).String())
+
+	b, err := SerializeData(NewInt128(-2))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, b)
+}
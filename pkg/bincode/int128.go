@@ -0,0 +1,47 @@
+package bincode
+
+import (
+	"math/big"
+)
+
+// Uint128 is a u128, it is encoded as Lo then Hi in little-endian which is the
+// little-endian encoding of the whole u128
+type Uint128 struct {
+	Lo uint64
+	Hi uint64
+}
+
+func NewUint128(n uint64) Uint128 {
+	return Uint128{Lo: n}
+}
+
+func (u Uint128) BigInt() *big.Int {
+	n := new(big.Int).SetUint64(u.Hi)
+	n.Lsh(n, 64)
+	return n.Or(n, new(big.Int).SetUint64(u.Lo))
+}
+
+func (u Uint128) String() string {
+	return u.BigInt().String()
+}
+
+// Int128 is an i128 in two's complement, encoded the same way as Uint128
+type Int128 struct {
+	Lo uint64
+	Hi int64
+}
+
+func NewInt128(n int64) Int128 {
+	// the high bits are the sign extension
+	return Int128{Lo: uint64(n), Hi: n >> 63}
+}
+
+func (i Int128) BigInt() *big.Int {
+	n := big.NewInt(i.Hi)
+	n.Lsh(n, 64)
+	return n.Add(n, new(big.Int).SetUint64(i.Lo))
+}
+
+func (i Int128) String() string {
+	return i.BigInt().String()
+}
@@ -27,6 +27,8 @@ func serializeData(v reflect.Value) ([]byte, error) {
 		return []byte{0}, nil
 	case reflect.Uint8:
 		return []byte{uint8(v.Uint())}, nil
+	case reflect.Int8:
+		return []byte{uint8(v.Int())}, nil
 	case reflect.Int16:
 		b := make([]byte, 2)
 		binary.LittleEndian.PutUint16(b, uint16(v.Int()))
@@ -52,35 +54,30 @@ func serializeData(v reflect.Value) ([]byte, error) {
 		binary.LittleEndian.PutUint64(b, v.Uint())
 		return b, nil
 	case reflect.Slice:
-		switch v.Type().Elem().Kind() {
-		case reflect.Array:
-			l := v.Len()
-			output := make([]byte, 0, 8+l*v.Type().Elem().Len())
-
-			b := make([]byte, 8)
-			binary.LittleEndian.PutUint64(b, uint64(v.Len()))
-
-			output = append(output, b...)
-			for i := 0; i < l; i++ {
-				d, err := serializeData(v.Index(i))
-				if err != nil {
-					return nil, err
-				}
-				output = append(output, d...)
+		l := v.Len()
+		output := make([]byte, 8, 8+l)
+		binary.LittleEndian.PutUint64(output, uint64(l))
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return append(output, v.Bytes()...), nil
+		}
+		for i := 0; i < l; i++ {
+			d, err := serializeData(v.Index(i))
+			if err != nil {
+				return nil, err
 			}
-			return output, nil
+			output = append(output, d...)
 		}
-		return nil, fmt.Errorf("unsupport type: %v, elem: %v", v.Kind(), v.Elem().Kind())
+		return output, nil
 	case reflect.Array:
-		switch v.Type().Elem().Kind() {
-		case reflect.Uint8:
-			b := make([]byte, 0, v.Len())
-			for i := 0; i < v.Len(); i++ {
-				b = append(b, byte(v.Index(i).Uint()))
+		output := make([]byte, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			d, err := serializeData(v.Index(i))
+			if err != nil {
+				return nil, err
 			}
-			return b, nil
+			output = append(output, d...)
 		}
-		return nil, fmt.Errorf("unsupport type: %v, elem: %v", v.Kind(), v.Elem().Kind())
+		return output, nil
 	case reflect.String:
 		b := make([]byte, 8+len(v.String()))
 		binary.LittleEndian.PutUint64(b, uint64(len(v.String())))
@@ -99,16 +96,60 @@ func serializeData(v reflect.Value) ([]byte, error) {
 		copy(b[1:], d[:])
 		return b, nil
 	case reflect.Struct:
-		data := make([]byte, 0, 1024)
-		for i := 0; i < v.NumField(); i++ {
-			field := v.Field(i)
-			d, err := serializeData(field)
+		return serializeStruct(v)
+	}
+	return nil, fmt.Errorf("unsupport type: %v", v.Kind())
+}
+
+func serializeStruct(v reflect.Value) ([]byte, error) {
+	tags, err := structTags(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, 1024)
+	var discriminant uint64
+	for i := 0; i < v.NumField(); i++ {
+		tag := tags[i]
+		field := v.Field(i)
+		switch {
+		case tag.skip:
+			continue
+		case tag.hasVariant && tag.variant != discriminant:
+			continue
+		case tag.coption:
+			d, err := serializeCOption(field)
 			if err != nil {
 				return nil, err
 			}
 			data = append(data, d...)
+			continue
+		case tag.enum:
+			discriminant = field.Uint()
+			if !tags.hasVariant(discriminant) {
+				return nil, fmt.Errorf("%w: %v", ErrUnknownVariant, discriminant)
+			}
+		}
+		d, err := serializeData(field)
+		if err != nil {
+			return nil, err
 		}
-		return data, nil
+		data = append(data, d...)
 	}
-	return nil, fmt.Errorf("unsupport type: %v", v.Kind())
+	return data, nil
+}
+
+// serializeCOption encodes a u32 tag followed by the value, zeros if it is nil
+func serializeCOption(v reflect.Value) ([]byte, error) {
+	tag, elem := uint32(1), v
+	if v.IsNil() {
+		tag, elem = 0, reflect.New(v.Type().Elem())
+	}
+	d, err := serializeData(elem.Elem())
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 4, 4+len(d))
+	binary.LittleEndian.PutUint32(b, tag)
+	return append(b, d...), nil
 }
@@ -0,0 +1,107 @@
+package bincode
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type fieldTag struct {
+	skip       bool
+	coption    bool
+	enum       bool
+	hasVariant bool
+	variant    uint64
+}
+
+type fieldTags []fieldTag
+
+// hasVariant reports whether a variant field of n exists, any discriminant is valid
+// if the struct has no variant fields
+func (tags fieldTags) hasVariant(n uint64) bool {
+	found := false
+	for _, tag := range tags {
+		if !tag.hasVariant {
+			continue
+		}
+		if tag.variant == n {
+			return true
+		}
+		found = true
+	}
+	return !found
+}
+
+var structTagsCache sync.Map
+
+// structTags parses the bincode tags of each field of the struct type t
+func structTags(t reflect.Type) (fieldTags, error) {
+	if cached, ok := structTagsCache.Load(t); ok {
+		return cached.(fieldTags), nil
+	}
+
+	tags := make(fieldTags, t.NumField())
+	hasEnum := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, err := parseFieldTag(field.Tag.Get("bincode"))
+		if err != nil {
+			return nil, fmt.Errorf("%v.%v: %w", t.Name(), field.Name, err)
+		}
+		if !tag.skip && !field.IsExported() {
+			return nil, fmt.Errorf("%v.%v: %w, unexported field", t.Name(), field.Name, ErrUnsupportedType)
+		}
+		switch {
+		case tag.coption && field.Type.Kind() != reflect.Ptr:
+			return nil, fmt.Errorf("%v.%v: %w, coption must be a pointer", t.Name(), field.Name, ErrUnsupportedType)
+		case tag.enum && hasEnum:
+			return nil, fmt.Errorf("%v.%v: %w, more than one enum field", t.Name(), field.Name, ErrUnsupportedType)
+		case tag.enum && !isUint(field.Type.Kind()):
+			return nil, fmt.Errorf("%v.%v: %w, enum must be an unsigned int", t.Name(), field.Name, ErrUnsupportedType)
+		case tag.hasVariant && !hasEnum:
+			return nil, fmt.Errorf("%v.%v: %w, variant before the enum field", t.Name(), field.Name, ErrUnsupportedType)
+		}
+		hasEnum = hasEnum || tag.enum
+		tags[i] = tag
+	}
+
+	structTagsCache.Store(t, tags)
+	return tags, nil
+}
+
+func parseFieldTag(s string) (fieldTag, error) {
+	var tag fieldTag
+	if s == "" {
+		return tag, nil
+	}
+	for _, option := range strings.Split(s, ",") {
+		switch option = strings.TrimSpace(option); {
+		case option == "-":
+			tag.skip = true
+		case option == "coption":
+			tag.coption = true
+		case option == "enum":
+			tag.enum = true
+		case strings.HasPrefix(option, "variant="):
+			n, err := strconv.ParseUint(strings.TrimPrefix(option, "variant="), 10, 64)
+			if err != nil {
+				return fieldTag{}, fmt.Errorf("invalid bincode tag %q", s)
+			}
+			tag.hasVariant = true
+			tag.variant = n
+		default:
+			return fieldTag{}, fmt.Errorf("invalid bincode tag %q", s)
+		}
+	}
+	return tag, nil
+}
+
+func isUint(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
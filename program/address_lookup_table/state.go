@@ -11,16 +11,14 @@ const LOOKUP_TABLE_MAX_ADDRESSES uint = 256
 const LOOKUP_TABLE_META_SIZE uint = 56
 
 func DeriveLookupTableAddress(authorityAddr common.PublicKey, recentBlockSlot uint64) (common.PublicKey, uint8) {
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, recentBlockSlot)
 	pubkey, bump, _ := common.FindProgramAddress(
-		[][]byte{
-			authorityAddr.Bytes(),
-			b[:],
-		},
+		common.Seeds(
+			common.SeedPublicKey(authorityAddr),
+			common.SeedUint64(recentBlockSlot),
+		),
 		common.AddressLookupTableProgramID,
 	)
-	return pubkey, uint8(bump)
+	return pubkey, bump
 }
 
 type ProgramStateEnum uint32
@@ -72,17 +70,25 @@ func DeserializeLookupTable(data []byte, accountOwner common.PublicKey) (Address
 		addressLookupTable.LastExtendedSlotStartIndex = data[current]
 		current += 1
 
-		some := bool(data[current] == 1)
+		option := data[current]
 		current += 1
-		if some {
+		switch option {
+		case 0:
+		case 1:
 			pubkey := common.PublicKeyFromBytes(data[current : current+32])
-			current += 32
 			addressLookupTable.Authority = &pubkey
+		default:
+			return AddressLookupTable{}, ErrInvalidAccountData
 		}
+		// the authority takes its space even if it is none
+		current += 32
 
 		addressLookupTable.padding = binary.LittleEndian.Uint16(data[current : current+2])
 		current += 2
 
+		if (len(data)-current)%32 != 0 {
+			return AddressLookupTable{}, ErrInvalidAccountDataSize
+		}
 		l := (len(data) - current) / 32
 		addresses := make([]common.PublicKey, 0, l)
 		for i := 0; i < l; i++ {
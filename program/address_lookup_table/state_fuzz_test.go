@@ -0,0 +1,22 @@
+package address_lookup_table
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzDeserializeLookupTable(f *testing.F) {
+	meta := []byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	f.Add(append(append(meta, common.TokenProgramID.Bytes()...), 0, 0))
+	f.Add(append(append(append(meta, common.TokenProgramID.Bytes()...), 0, 0), common.SystemProgramID.Bytes()...))
+	f.Add([]byte{0, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		table, err := DeserializeLookupTable(data, common.AddressLookupTableProgramID)
+		if err != nil || table.ProgramState == ProgramStateUninitialized {
+			return
+		}
+		assert.Equal(t, (len(data)-int(LOOKUP_TABLE_META_SIZE))/32, len(table.Addresses))
+	})
+}
@@ -96,6 +96,46 @@ func TestDeserializeLookupTable(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "no authority",
+			args: args{
+				data:         append(append([]byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 34)...), common.TokenProgramID.Bytes()...),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want: AddressLookupTable{
+				ProgramState:     ProgramStateLookupTable,
+				DeactivationSlot: ^uint64(0),
+				Addresses:        []common.PublicKey{common.TokenProgramID},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid authority option",
+			args: args{
+				data:         append([]byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}, make([]byte, 34)...),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountData,
+		},
+		{
+			name: "partial address",
+			args: args{
+				data:         append([]byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 34+31)...),
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountDataSize,
+		},
+		{
+			name: "short",
+			args: args{
+				data:         []byte{1, 0, 0, 0, 255},
+				accountOwner: common.AddressLookupTableProgramID,
+			},
+			want:    AddressLookupTable{},
+			wantErr: ErrInvalidAccountDataSize,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,66 @@
+package address_lookup_table
+
+import (
+	"math"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/sysvar"
+)
+
+// LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT is the deactivation slot of a table which isn't deactivated
+const LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT uint64 = math.MaxUint64
+
+type LookupTableStatus uint8
+
+const (
+	LookupTableStatusActivated LookupTableStatus = iota
+	LookupTableStatusDeactivating
+	LookupTableStatusDeactivated
+)
+
+func (s LookupTableStatus) String() string {
+	switch s {
+	case LookupTableStatusActivated:
+		return "activated"
+	case LookupTableStatusDeactivating:
+		return "deactivating"
+	case LookupTableStatusDeactivated:
+		return "deactivated"
+	}
+	return "unknown"
+}
+
+// Status returns the status at the slot. a deactivated table stays deactivating until
+// its deactivation slot leaves the slot hashes, then it can be closed.
+func (t AddressLookupTable) Status(currentSlot uint64, slotHashes sysvar.SlotHashes) LookupTableStatus {
+	switch {
+	case t.DeactivationSlot == LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT:
+		return LookupTableStatusActivated
+	case t.DeactivationSlot == currentSlot:
+		return LookupTableStatusDeactivating
+	case slotHashes.Position(t.DeactivationSlot) >= 0:
+		return LookupTableStatusDeactivating
+	default:
+		return LookupTableStatusDeactivated
+	}
+}
+
+// RemainingBlocks returns how many blocks a deactivating table needs to be deactivated
+func (t AddressLookupTable) RemainingBlocks(currentSlot uint64, slotHashes sysvar.SlotHashes) uint64 {
+	if t.Status(currentSlot, slotHashes) != LookupTableStatusDeactivating {
+		return 0
+	}
+	if t.DeactivationSlot == currentSlot {
+		return sysvar.SlotHashesMaxEntries + 1
+	}
+	return uint64(sysvar.SlotHashesMaxEntries - slotHashes.Position(t.DeactivationSlot))
+}
+
+// ActiveAddresses returns addresses which can be looked up at the slot. addresses
+// extended at a slot warm up and become active from the next slot.
+func (t AddressLookupTable) ActiveAddresses(currentSlot uint64) []common.PublicKey {
+	if currentSlot > t.LastExtendedSlot || int(t.LastExtendedSlotStartIndex) > len(t.Addresses) {
+		return t.Addresses
+	}
+	return t.Addresses[:t.LastExtendedSlotStartIndex]
+}
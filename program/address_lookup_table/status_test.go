@@ -0,0 +1,70 @@
+package address_lookup_table
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/sysvar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressLookupTable_Status(t *testing.T) {
+	slotHashes := sysvar.SlotHashes{{Slot: 105}, {Slot: 104}, {Slot: 102}}
+	tests := []struct {
+		name             string
+		deactivationSlot uint64
+		status           LookupTableStatus
+		remainingBlocks  uint64
+	}{
+		{
+			name:             "activated",
+			deactivationSlot: LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT,
+			status:           LookupTableStatusActivated,
+			remainingBlocks:  0,
+		},
+		{
+			name:             "deactivated in the current slot",
+			deactivationSlot: 106,
+			status:           LookupTableStatusDeactivating,
+			remainingBlocks:  513,
+		},
+		{
+			name:             "deactivating",
+			deactivationSlot: 104,
+			status:           LookupTableStatusDeactivating,
+			remainingBlocks:  511,
+		},
+		{
+			name:             "deactivated",
+			deactivationSlot: 100,
+			status:           LookupTableStatusDeactivated,
+			remainingBlocks:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := AddressLookupTable{ProgramState: ProgramStateLookupTable, DeactivationSlot: tt.deactivationSlot}
+			assert.Equal(t, tt.status, table.Status(106, slotHashes))
+			assert.Equal(t, tt.remainingBlocks, table.RemainingBlocks(106, slotHashes))
+		})
+	}
+	assert.Equal(t, "deactivating", LookupTableStatusDeactivating.String())
+}
+
+func TestAddressLookupTable_ActiveAddresses(t *testing.T) {
+	addresses := []common.PublicKey{
+		common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz"),
+		common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"),
+		common.PublicKeyFromString("DJyNpXgggw1WGgjTVzFsNjb3fuQZVMqhoakvSBfX9LYx"),
+	}
+	table := AddressLookupTable{
+		ProgramState:               ProgramStateLookupTable,
+		DeactivationSlot:           LOOKUP_TABLE_ACTIVE_DEACTIVATION_SLOT,
+		LastExtendedSlot:           100,
+		LastExtendedSlotStartIndex: 1,
+		Addresses:                  addresses,
+	}
+	// the last extension warms up in its slot
+	assert.Equal(t, addresses[:1], table.ActiveAddresses(100))
+	assert.Equal(t, addresses, table.ActiveAddresses(101))
+}
@@ -0,0 +1,59 @@
+package token_metadata
+
+import "github.com/blocto/solana-go-sdk/types"
+
+// ProgramErrors are the custom errors of the token metadata program
+var ProgramErrors = []types.ProgramError{
+	{Program: "token metadata", Code: 0, Name: "InstructionUnpackError", Message: "failed to unpack instruction data"},
+	{Program: "token metadata", Code: 1, Name: "InstructionPackError", Message: "failed to pack instruction data"},
+	{Program: "token metadata", Code: 2, Name: "NotRentExempt", Message: "lamport balance below rent-exempt threshold"},
+	{Program: "token metadata", Code: 3, Name: "AlreadyInitialized", Message: "already initialized"},
+	{Program: "token metadata", Code: 4, Name: "Uninitialized", Message: "uninitialized"},
+	{Program: "token metadata", Code: 5, Name: "InvalidMetadataKey", Message: "metadata's key must match seed of ['metadata', program id, mint] provided"},
+	{Program: "token metadata", Code: 6, Name: "InvalidEditionKey", Message: "edition's key must match seed of ['metadata', program id, name, 'edition'] provided"},
+	{Program: "token metadata", Code: 7, Name: "UpdateAuthorityIncorrect", Message: "update authority given does not match"},
+	{Program: "token metadata", Code: 8, Name: "UpdateAuthorityIsNotSigner", Message: "update authority needs to be signer to update metadata"},
+	{Program: "token metadata", Code: 9, Name: "NotMintAuthority", Message: "you must be the mint authority and signer on this transaction"},
+	{Program: "token metadata", Code: 10, Name: "InvalidMintAuthority", Message: "mint authority provided does not match the authority on the mint"},
+	{Program: "token metadata", Code: 11, Name: "NameTooLong", Message: "name too long"},
+	{Program: "token metadata", Code: 12, Name: "SymbolTooLong", Message: "symbol too long"},
+	{Program: "token metadata", Code: 13, Name: "UriTooLong", Message: "uri too long"},
+	{Program: "token metadata", Code: 14, Name: "UpdateAuthorityMustBeEqualToMetadataAuthorityAndSigner", Message: "update authority must be equivalent to the metadata's authority and also signer of this transaction"},
+	{Program: "token metadata", Code: 15, Name: "MintMismatch", Message: "mint given does not match mint on metadata"},
+	{Program: "token metadata", Code: 16, Name: "EditionsMustHaveExactlyOneToken", Message: "editions must have exactly one token"},
+	{Program: "token metadata", Code: 17, Name: "MaxEditionsMintedAlready", Message: "maximum editions printed already"},
+	{Program: "token metadata", Code: 18, Name: "TokenMintToFailed", Message: "token mint to failed"},
+	{Program: "token metadata", Code: 19, Name: "MasterRecordMismatch", Message: "the master edition record passed must match the master record on the edition given"},
+	{Program: "token metadata", Code: 20, Name: "DestinationMintMismatch", Message: "the destination account does not have the right mint"},
+	{Program: "token metadata", Code: 21, Name: "EditionAlreadyMinted", Message: "an edition can only mint one of its kind"},
+	{Program: "token metadata", Code: 22, Name: "PrintingMintDecimalsShouldBeZero", Message: "printing mint decimals should be zero"},
+	{Program: "token metadata", Code: 23, Name: "OneTimePrintingAuthorizationMintDecimalsShouldBeZero", Message: "one time printing authorization mint decimals should be zero"},
+	{Program: "token metadata", Code: 24, Name: "EditionMintDecimalsShouldBeZero", Message: "edition mint decimals should be zero"},
+	{Program: "token metadata", Code: 25, Name: "TokenBurnFailed", Message: "token burn failed"},
+	{Program: "token metadata", Code: 26, Name: "TokenAccountOneTimeAuthMintMismatch", Message: "the one time authorization mint does not match that on the token account"},
+	{Program: "token metadata", Code: 27, Name: "DerivedKeyInvalid", Message: "derived key invalid"},
+	{Program: "token metadata", Code: 28, Name: "PrintingMintMismatch", Message: "the printing mint does not match that on the master edition"},
+	{Program: "token metadata", Code: 29, Name: "OneTimePrintingAuthMintMismatch", Message: "the one time printing auth mint does not match that on the master edition"},
+	{Program: "token metadata", Code: 30, Name: "TokenAccountMintMismatch", Message: "the mint of the token account does not match the printing mint"},
+	{Program: "token metadata", Code: 31, Name: "TokenAccountMintMismatchV2", Message: "the mint of the token account does not match the master metadata mint"},
+	{Program: "token metadata", Code: 32, Name: "NotEnoughTokens", Message: "this token account has not enough tokens to perform this action"},
+	{Program: "token metadata", Code: 33, Name: "PrintingMintAuthorizationAccountMismatch", Message: "the printing mint authorization account does not match that on the master edition"},
+	{Program: "token metadata", Code: 34, Name: "AuthorizationTokenAccountOwnerMismatch", Message: "the authorization token account has a different owner than the update authority for the master edition"},
+	{Program: "token metadata", Code: 35, Name: "Disabled", Message: "this feature is currently disabled"},
+	{Program: "token metadata", Code: 36, Name: "CreatorsTooLong", Message: "creators list too long"},
+	{Program: "token metadata", Code: 37, Name: "CreatorsMustBeAtleastOne", Message: "creators must be at least one if set"},
+	{Program: "token metadata", Code: 38, Name: "MustBeOneOfCreators", Message: "if using a creators array, you must be one of the creators listed"},
+	{Program: "token metadata", Code: 39, Name: "NoCreatorsPresentOnMetadata", Message: "this metadata does not have creators"},
+	{Program: "token metadata", Code: 40, Name: "CreatorNotFound", Message: "this creator address was not found"},
+	{Program: "token metadata", Code: 41, Name: "InvalidBasisPoints", Message: "basis points cannot be more than 10000"},
+	{Program: "token metadata", Code: 42, Name: "PrimarySaleCanOnlyBeFlippedToTrue", Message: "primary sale can only be flipped to true and is immutable"},
+	{Program: "token metadata", Code: 43, Name: "OwnerMismatch", Message: "owner does not match that on the account given"},
+	{Program: "token metadata", Code: 44, Name: "NoBalanceInAccountForAuthorization", Message: "this account has no tokens to be used for authorization"},
+	{Program: "token metadata", Code: 45, Name: "ShareTotalMustBe100", Message: "share total must equal 100 for creator array"},
+	{Program: "token metadata", Code: 46, Name: "ReservationExists", Message: "this reservation list already exists"},
+	{Program: "token metadata", Code: 47, Name: "ReservationDoesNotExist", Message: "this reservation list does not exist"},
+	{Program: "token metadata", Code: 48, Name: "ReservationNotSet", Message: "this reservation list exists but was never set with reservations"},
+	{Program: "token metadata", Code: 49, Name: "ReservationAlreadyMade", Message: "this reservation list has already been set"},
+	{Program: "token metadata", Code: 50, Name: "BeyondMaxAddressSize", Message: "provided more addresses than max allowed in single reservation"},
+	{Program: "token metadata", Code: 51, Name: "NumericalOverflowError", Message: "numerical overflow error"},
+}
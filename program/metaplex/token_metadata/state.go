@@ -1,6 +1,7 @@
 package token_metadata
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strings"
 
@@ -121,7 +122,33 @@ type ProgrammableConfigV1 struct {
 	RuleSet *common.PublicKey
 }
 
+// metadataStringsOffset is where Data.Name starts, after the key, update authority and mint
+const metadataStringsOffset = 1 + 32 + 32
+
+// checkMetadataStrings makes sure the lengths of name, symbol and uri fit in data.
+// borsh allocates a string by its u32 length before reading it, a malformed length
+// would allocate up to 4GB.
+func checkMetadataStrings(data []byte) error {
+	current := metadataStringsOffset
+	for _, field := range []string{"name", "symbol", "uri"} {
+		if len(data)-current < 4 {
+			return fmt.Errorf("failed to deserialize data, err: %v is too short", field)
+		}
+		l := binary.LittleEndian.Uint32(data[current : current+4])
+		current += 4
+		if uint64(l) > uint64(len(data)-current) {
+			return fmt.Errorf("failed to deserialize data, err: %v length %v exceeds remaining data %v", field, l, len(data)-current)
+		}
+		current += int(l)
+	}
+	return nil
+}
+
 func MetadataDeserialize(data []byte) (Metadata, error) {
+	if err := checkMetadataStrings(data); err != nil {
+		return Metadata{}, err
+	}
+
 	var metadata Metadata
 	err := borsh.Deserialize(&metadata, data)
 	if err != nil {
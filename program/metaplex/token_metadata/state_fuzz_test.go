@@ -0,0 +1,42 @@
+package token_metadata
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/near/borsh-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzMetadataDeserialize(f *testing.F) {
+	seed, err := borsh.Serialize(Metadata{
+		Key:             KeyMetadataV1,
+		UpdateAuthority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Mint:            common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"),
+		Data: Data{
+			Name:   "name",
+			Symbol: "SYM",
+			Uri:    "https://example.com",
+			Creators: &[]Creator{
+				{Address: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), Verified: true, Share: 100},
+			},
+		},
+		IsMutable: true,
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add(append(make([]byte, 65), 255, 255, 255, 255))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		metadata, err := MetadataDeserialize(data)
+		if err != nil {
+			return
+		}
+		b, err := borsh.Serialize(metadata)
+		assert.Nil(t, err)
+		got, err := MetadataDeserialize(b)
+		assert.Nil(t, err)
+		assert.Equal(t, metadata, got)
+	})
+}
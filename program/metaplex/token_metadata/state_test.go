@@ -161,3 +161,12 @@ func TestMetadataDeserialize(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataDeserialize_InvalidData(t *testing.T) {
+	_, err := MetadataDeserialize(make([]byte, 10))
+	assert.EqualError(t, err, "failed to deserialize data, err: name is too short")
+
+	// a huge name length must not be allocated
+	_, err = MetadataDeserialize(append(make([]byte, 65), 255, 255, 255, 255, 0))
+	assert.EqualError(t, err, "failed to deserialize data, err: name length 4294967295 exceeds remaining data 1")
+}
@@ -8,11 +8,11 @@ import (
 
 func GetTokenMetaPubkey(mint common.PublicKey) (common.PublicKey, error) {
 	metadataAccount, _, err := common.FindProgramAddress(
-		[][]byte{
-			[]byte("metadata"),
-			common.MetaplexTokenMetaProgramID.Bytes(),
-			mint.Bytes(),
-		},
+		common.Seeds(
+			common.SeedString("metadata"),
+			common.SeedPublicKey(common.MetaplexTokenMetaProgramID),
+			common.SeedPublicKey(mint),
+		),
 		common.MetaplexTokenMetaProgramID,
 	)
 	if err != nil {
@@ -23,12 +23,12 @@ func GetTokenMetaPubkey(mint common.PublicKey) (common.PublicKey, error) {
 
 func GetMasterEdition(mint common.PublicKey) (common.PublicKey, error) {
 	msaterEdtion, _, err := common.FindProgramAddress(
-		[][]byte{
-			[]byte("metadata"),
-			common.MetaplexTokenMetaProgramID.Bytes(),
-			mint.Bytes(),
-			[]byte("edition"),
-		},
+		common.Seeds(
+			common.SeedString("metadata"),
+			common.SeedPublicKey(common.MetaplexTokenMetaProgramID),
+			common.SeedPublicKey(mint),
+			common.SeedString("edition"),
+		),
 		common.MetaplexTokenMetaProgramID,
 	)
 	if err != nil {
@@ -40,13 +40,13 @@ func GetMasterEdition(mint common.PublicKey) (common.PublicKey, error) {
 func GetEditionMark(mint common.PublicKey, edition uint64) (common.PublicKey, error) {
 	editionNumber := edition / EDITION_MARKER_BIT_SIZE
 	pubkey, _, err := common.FindProgramAddress(
-		[][]byte{
-			[]byte("metadata"),
-			common.MetaplexTokenMetaProgramID.Bytes(),
-			mint.Bytes(),
-			[]byte("edition"),
-			[]byte(strconv.FormatUint(editionNumber, 10)),
-		},
+		common.Seeds(
+			common.SeedString("metadata"),
+			common.SeedPublicKey(common.MetaplexTokenMetaProgramID),
+			common.SeedPublicKey(mint),
+			common.SeedString("edition"),
+			common.SeedString(strconv.FormatUint(editionNumber, 10)),
+		),
 		common.MetaplexTokenMetaProgramID,
 	)
 	return pubkey, err
@@ -20,12 +20,14 @@ func GetHashName(name string) []byte {
 
 // GetNameAccountKey return the pubkey correspond to name
 func GetNameAccountKey(hashName []byte, nameClass, nameParent common.PublicKey) common.PublicKey {
-	seed := [][]byte{
-		hashName,
-		nameClass.Bytes(),
-		nameParent.Bytes(),
-	}
-	pubkey, _, _ := common.FindProgramAddress(seed, common.SPLNameServiceProgramID)
+	pubkey, _, _ := common.FindProgramAddress(
+		common.Seeds(
+			common.SeedBytes(hashName),
+			common.SeedPublicKey(nameClass),
+			common.SeedPublicKey(nameParent),
+		),
+		common.SPLNameServiceProgramID,
+	)
 	return pubkey
 }
 
@@ -0,0 +1,24 @@
+package stake
+
+import "github.com/blocto/solana-go-sdk/types"
+
+// ProgramErrors are the custom errors of the stake program
+var ProgramErrors = []types.ProgramError{
+	{Program: "stake", Code: 0, Name: "NoCreditsToRedeem", Message: "not enough credits to redeem"},
+	{Program: "stake", Code: 1, Name: "LockupInForce", Message: "lockup has not yet expired"},
+	{Program: "stake", Code: 2, Name: "AlreadyDeactivated", Message: "stake already deactivated"},
+	{Program: "stake", Code: 3, Name: "TooSoonToRedelegate", Message: "one re-delegation permitted per epoch"},
+	{Program: "stake", Code: 4, Name: "InsufficientStake", Message: "split amount is more than is staked"},
+	{Program: "stake", Code: 5, Name: "MergeTransientStake", Message: "stake account with transient stake cannot be merged"},
+	{Program: "stake", Code: 6, Name: "MergeMismatch", Message: "stake account merge failed due to different authority, lockups or state"},
+	{Program: "stake", Code: 7, Name: "CustodianMissing", Message: "custodian address not present"},
+	{Program: "stake", Code: 8, Name: "CustodianSignatureMissing", Message: "custodian signature not present"},
+	{Program: "stake", Code: 9, Name: "InsufficientReferenceVotes", Message: "insufficient voting activity in the reference vote account"},
+	{Program: "stake", Code: 10, Name: "VoteAddressMismatch", Message: "stake account is not delegated to the provided vote account"},
+	{Program: "stake", Code: 11, Name: "MinimumDelinquentEpochsForDeactivationNotMet", Message: "stake account has not been delinquent for the minimum epochs required for deactivation"},
+	{Program: "stake", Code: 12, Name: "InsufficientDelegation", Message: "delegation amount is less than the minimum"},
+	{Program: "stake", Code: 13, Name: "RedelegateTransientOrInactiveStake", Message: "stake account with transient or inactive stake cannot be redelegated"},
+	{Program: "stake", Code: 14, Name: "RedelegateToSameVoteAccount", Message: "stake redelegation to the same vote account is not permitted"},
+	{Program: "stake", Code: 15, Name: "RedelegatedStakeMustFullyActivateBeforeDeactivationIsPermitted", Message: "redelegated stake must be fully activated before deactivation"},
+	{Program: "stake", Code: 16, Name: "EpochRewardsActive", Message: "stake action is not permitted while the epoch rewards period is active"},
+}
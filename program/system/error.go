@@ -0,0 +1,16 @@
+package system
+
+import "github.com/blocto/solana-go-sdk/types"
+
+// ProgramErrors are the custom errors of the system program, nonce errors included
+var ProgramErrors = []types.ProgramError{
+	{Program: "system", Code: 0, Name: "AccountAlreadyInUse", Message: "an account with the same address already exists"},
+	{Program: "system", Code: 1, Name: "ResultWithNegativeLamports", Message: "account does not have enough SOL to perform the operation"},
+	{Program: "system", Code: 2, Name: "InvalidProgramId", Message: "cannot assign account to this program id"},
+	{Program: "system", Code: 3, Name: "InvalidAccountDataLength", Message: "cannot allocate account data of this length"},
+	{Program: "system", Code: 4, Name: "MaxSeedLengthExceeded", Message: "length of requested seed is too long"},
+	{Program: "system", Code: 5, Name: "AddressWithSeedMismatch", Message: "provided address does not match addressed derived from seed"},
+	{Program: "system", Code: 6, Name: "NonceNoRecentBlockhashes", Message: "advancing stored nonce requires a populated RecentBlockhashes sysvar"},
+	{Program: "system", Code: 7, Name: "NonceBlockhashNotExpired", Message: "stored nonce is still in recent_blockhashes"},
+	{Program: "system", Code: 8, Name: "NonceUnexpectedBlockhashValue", Message: "specified nonce does not match stored nonce"},
+}
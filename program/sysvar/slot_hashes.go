@@ -5,6 +5,9 @@ import (
 	"github.com/blocto/solana-go-sdk/pkg/bytes_decoder"
 )
 
+// SlotHashesMaxEntries is the number of recent slots the sysvar keeps
+const SlotHashesMaxEntries = 512
+
 type SlotHash struct {
 	Slot uint64
 	Hash [32]byte
@@ -18,13 +21,18 @@ func DeserializeSlotHashes(data []byte, owner common.PublicKey) (SlotHashes, err
 	}
 
 	current := 0
-	len, err := bytes_decoder.GetUint64(&current, data)
+	count, err := bytes_decoder.GetUint64(&current, data)
 	if err != nil {
 		return SlotHashes{}, err
 	}
 
-	v := make([]SlotHash, 0, len)
-	for i := uint64(0); i < len; i++ {
+	// each entry takes 40 bytes, don't trust the length before allocating
+	if count > uint64(len(data)-current)/40 {
+		return SlotHashes{}, ErrInvalidAccountDataSize
+	}
+
+	v := make([]SlotHash, 0, count)
+	for i := uint64(0); i < count; i++ {
 		slot, err := bytes_decoder.GetUint64(&current, data)
 		if err != nil {
 			return SlotHashes{}, err
@@ -38,3 +46,13 @@ func DeserializeSlotHashes(data []byte, owner common.PublicKey) (SlotHashes, err
 	}
 	return v, nil
 }
+
+// Position returns the index of the slot, -1 if the slot isn't in the list
+func (s SlotHashes) Position(slot uint64) int {
+	for i, slotHash := range s {
+		if slotHash.Slot == slot {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,20 @@
+package sysvar
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzDeserializeSlotHashes(f *testing.F) {
+	f.Add(append([]byte{1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 32)...))
+	f.Add([]byte{255, 255, 255, 255, 255, 255, 255, 255})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		slotHashes, err := DeserializeSlotHashes(data, common.SysVarPubkey)
+		if err != nil {
+			return
+		}
+		assert.LessOrEqual(t, 8+40*len(slotHashes), len(data))
+	})
+}
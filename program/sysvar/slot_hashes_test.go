@@ -52,6 +52,15 @@ func TestDeserializeSlotHashes(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "huge length",
+			args: args{
+				data:  []byte{255, 255, 255, 255, 255, 255, 255, 255, 1, 0, 0, 0, 0, 0, 0, 0},
+				owner: common.SysVarPubkey,
+			},
+			want: SlotHashes{},
+			err:  ErrInvalidAccountDataSize,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,8 +1,36 @@
 package token
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/blocto/solana-go-sdk/types"
+)
 
 var (
 	ErrInvalidAccountOwner    = errors.New("invalid account owner")
 	ErrInvalidAccountDataSize = errors.New("invalid account data size")
 )
+
+// ProgramErrors are the custom errors of the token program, token-2022 shares the same codes
+var ProgramErrors = []types.ProgramError{
+	{Program: "token", Code: 0, Name: "NotRentExempt", Message: "lamport balance below rent-exempt threshold"},
+	{Program: "token", Code: 1, Name: "InsufficientFunds", Message: "insufficient funds"},
+	{Program: "token", Code: 2, Name: "InvalidMint", Message: "invalid mint"},
+	{Program: "token", Code: 3, Name: "MintMismatch", Message: "account not associated with this mint"},
+	{Program: "token", Code: 4, Name: "OwnerMismatch", Message: "owner does not match"},
+	{Program: "token", Code: 5, Name: "FixedSupply", Message: "fixed supply"},
+	{Program: "token", Code: 6, Name: "AlreadyInUse", Message: "already in use"},
+	{Program: "token", Code: 7, Name: "InvalidNumberOfProvidedSigners", Message: "invalid number of provided signers"},
+	{Program: "token", Code: 8, Name: "InvalidNumberOfRequiredSigners", Message: "invalid number of required signers"},
+	{Program: "token", Code: 9, Name: "UninitializedState", Message: "state is uninitialized"},
+	{Program: "token", Code: 10, Name: "NativeNotSupported", Message: "instruction does not support native tokens"},
+	{Program: "token", Code: 11, Name: "NonNativeHasBalance", Message: "non-native account can only be closed if its balance is zero"},
+	{Program: "token", Code: 12, Name: "InvalidInstruction", Message: "invalid instruction"},
+	{Program: "token", Code: 13, Name: "InvalidState", Message: "state is invalid for requested operation"},
+	{Program: "token", Code: 14, Name: "Overflow", Message: "operation overflowed"},
+	{Program: "token", Code: 15, Name: "AuthorityTypeNotSupported", Message: "account does not support specified authority type"},
+	{Program: "token", Code: 16, Name: "MintCannotFreeze", Message: "this token mint cannot freeze accounts"},
+	{Program: "token", Code: 17, Name: "AccountFrozen", Message: "account is frozen"},
+	{Program: "token", Code: 18, Name: "MintDecimalsMismatch", Message: "the provided decimals value different from the mint decimals"},
+	{Program: "token", Code: 19, Name: "NonNativeNotSupported", Message: "instruction does not support non-native tokens"},
+}
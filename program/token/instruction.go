@@ -481,6 +481,14 @@ type TransferCheckedParam struct {
 	Decimals uint8
 }
 
+// WithAmount sets Amount and Decimals from an exact amount, e.g. one from
+// MintAccount.ParseAmount
+func (p TransferCheckedParam) WithAmount(amount common.Amount) TransferCheckedParam {
+	p.Amount = amount.Raw
+	p.Decimals = amount.Decimals
+	return p
+}
+
 func TransferChecked(param TransferCheckedParam) types.Instruction {
 	data, err := bincode.SerializeData(struct {
 		Instruction Instruction
@@ -1,10 +1,8 @@
 package token
 
 import (
-	"bytes"
-	"encoding/binary"
-
 	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/pkg/bincode"
 )
 
 var (
@@ -56,11 +54,21 @@ func MultisigAccountFromData(data []byte) (MultisigAccount, error) {
 const MintAccountSize = 82
 
 type MintAccount struct {
-	MintAuthority   *common.PublicKey
+	MintAuthority   *common.PublicKey `bincode:"coption"`
 	Supply          uint64
 	Decimals        uint8
 	IsInitialized   bool
-	FreezeAuthority *common.PublicKey
+	FreezeAuthority *common.PublicKey `bincode:"coption"`
+}
+
+// Amount returns a raw amount of the mint with its decimals
+func (m MintAccount) Amount(raw uint64) common.Amount {
+	return common.NewAmount(raw, m.Decimals)
+}
+
+// ParseAmount parses a human amount like "1.5" with the decimals of the mint
+func (m MintAccount) ParseAmount(s string) (common.Amount, error) {
+	return common.ParseAmount(s, m.Decimals)
 }
 
 func MintAccountFromData(data []byte) (MintAccount, error) {
@@ -68,31 +76,11 @@ func MintAccountFromData(data []byte) (MintAccount, error) {
 		return MintAccount{}, ErrInvalidAccountDataSize
 	}
 
-	var mint *common.PublicKey
-	if bytes.Equal(data[:4], Some) {
-		key := common.PublicKeyFromBytes(data[4:36])
-		mint = &key
-	}
-
-	supply := binary.LittleEndian.Uint64(data[36:44])
-
-	decimals := uint8(data[44])
-
-	isInitialized := data[45] == 1
-
-	var freezeAuthority *common.PublicKey
-	if bytes.Equal(data[46:50], Some) {
-		key := common.PublicKeyFromBytes(data[50:82])
-		freezeAuthority = &key
+	var mint MintAccount
+	if err := bincode.DeserializeData(data, &mint); err != nil {
+		return MintAccount{}, err
 	}
-
-	return MintAccount{
-		MintAuthority:   mint,
-		Supply:          supply,
-		Decimals:        decimals,
-		IsInitialized:   isInitialized,
-		FreezeAuthority: freezeAuthority,
-	}, nil
+	return mint, nil
 }
 
 const TokenAccountSize = 165
@@ -110,12 +98,12 @@ type TokenAccount struct {
 	Mint     common.PublicKey
 	Owner    common.PublicKey
 	Amount   uint64
-	Delegate *common.PublicKey
+	Delegate *common.PublicKey `bincode:"coption"`
 	State    TokenAccountState
 	// if is wrapped SOL, IsNative is the rent-exempt value
-	IsNative        *uint64
+	IsNative        *uint64 `bincode:"coption"`
 	DelegatedAmount uint64
-	CloseAuthority  *common.PublicKey
+	CloseAuthority  *common.PublicKey `bincode:"coption"`
 }
 
 func TokenAccountFromData(data []byte) (TokenAccount, error) {
@@ -123,44 +111,11 @@ func TokenAccountFromData(data []byte) (TokenAccount, error) {
 		return TokenAccount{}, ErrInvalidAccountDataSize
 	}
 
-	mint := common.PublicKeyFromBytes(data[:32])
-
-	owner := common.PublicKeyFromBytes(data[32:64])
-
-	amount := binary.LittleEndian.Uint64(data[64:72])
-
-	var delegate *common.PublicKey
-	if bytes.Equal(data[72:76], Some) {
-		key := common.PublicKeyFromBytes(data[76:108])
-		delegate = &key
-	}
-
-	state := TokenAccountState(data[108])
-
-	var isNative *uint64
-	if bytes.Equal(data[109:113], Some) {
-		num := binary.LittleEndian.Uint64(data[113:121])
-		isNative = &num
+	var account TokenAccount
+	if err := bincode.DeserializeData(data, &account); err != nil {
+		return TokenAccount{}, err
 	}
-
-	delegatedAmount := binary.LittleEndian.Uint64(data[121:129])
-
-	var closeAuthority *common.PublicKey
-	if bytes.Equal(data[129:133], Some) {
-		key := common.PublicKeyFromBytes(data[133:165])
-		closeAuthority = &key
-	}
-
-	return TokenAccount{
-		Mint:            mint,
-		Owner:           owner,
-		Amount:          amount,
-		Delegate:        delegate,
-		State:           state,
-		IsNative:        isNative,
-		DelegatedAmount: delegatedAmount,
-		CloseAuthority:  closeAuthority,
-	}, nil
+	return account, nil
 }
 
 func DeserializeTokenAccount(data []byte, accountOwner common.PublicKey) (TokenAccount, error) {
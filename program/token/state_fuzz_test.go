@@ -0,0 +1,73 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/pkg/bincode"
+	"github.com/blocto/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzMintAccountFromData(f *testing.F) {
+	seed, err := bincode.SerializeData(MintAccount{
+		MintAuthority: pointer.Get[common.PublicKey](common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+		Supply:        1_000_000,
+		Decimals:      6,
+		IsInitialized: true,
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add(make([]byte, MintAccountSize-1))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		mint, err := MintAccountFromData(data)
+		if err != nil {
+			return
+		}
+		b, err := bincode.SerializeData(mint)
+		assert.Nil(t, err)
+		got, err := MintAccountFromData(b)
+		assert.Nil(t, err)
+		assert.Equal(t, mint, got)
+	})
+}
+
+func FuzzTokenAccountFromData(f *testing.F) {
+	seed, err := bincode.SerializeData(TokenAccount{
+		Mint:           common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"),
+		Owner:          common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Amount:         1049000000000,
+		State:          TokenAccountStateInitialized,
+		IsNative:       pointer.Get[uint64](2039280),
+		CloseAuthority: pointer.Get[common.PublicKey](common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add(make([]byte, TokenAccountSize+1))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		account, err := TokenAccountFromData(data)
+		if err != nil {
+			return
+		}
+		b, err := bincode.SerializeData(account)
+		assert.Nil(t, err)
+		got, err := TokenAccountFromData(b)
+		assert.Nil(t, err)
+		assert.Equal(t, account, got)
+	})
+}
+
+func FuzzMultisigAccountFromData(f *testing.F) {
+	f.Add(make([]byte, MultisigAccountSize))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		account, err := MultisigAccountFromData(data)
+		if err != nil {
+			return
+		}
+		assert.LessOrEqual(t, len(account.Signers), MaxSigners)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/pkg/bincode"
 	"github.com/blocto/solana-go-sdk/pkg/pointer"
 	"github.com/stretchr/testify/assert"
 )
@@ -265,3 +266,37 @@ func TestDeserializeTokenAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestMintAccount_ParseAmount(t *testing.T) {
+	mint := MintAccount{Decimals: 4}
+
+	amount, err := mint.ParseAmount("9.9999")
+	assert.Nil(t, err)
+	assert.Equal(t, common.NewAmount(99999, 4), amount)
+	assert.Equal(t, "9.9999", mint.Amount(99999).String())
+
+	_, err = mint.ParseAmount("9.99999")
+	assert.ErrorIs(t, err, common.ErrAmountTooPrecise)
+
+	param := TransferCheckedParam{
+		From: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+		To:   common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+		Mint: common.PublicKeyFromString("HFCNHUwPxRqqW6gaLd3uUjJcEUfjnRptJzh4xvnNmavv"),
+		Auth: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+	}.WithAmount(amount)
+	assert.Equal(t, uint64(99999), param.Amount)
+	assert.Equal(t, uint8(4), param.Decimals)
+	assert.Equal(t, []byte{12, 159, 134, 1, 0, 0, 0, 0, 0, 4}, TransferChecked(param).Data)
+}
+
+func TestMintAccountFromData_InvalidData(t *testing.T) {
+	data := make([]byte, MintAccountSize)
+	data[0] = 2
+	_, err := MintAccountFromData(data)
+	assert.ErrorIs(t, err, bincode.ErrInvalidOption)
+
+	data[0] = 0
+	data[45] = 2
+	_, err = MintAccountFromData(data)
+	assert.EqualError(t, err, "bincode: failed to decode MintAccount.IsInitialized at offset 45, err: invalid bool: 2")
+}
@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var ErrBatchResponseNotFound = errors.New("rpc: response not found in batch")
+
+// BatchRequest is a single call inside a batch request. use NewBatchCall to create one.
+type BatchRequest interface {
+	params() []any
+	setResponse(body []byte, err error)
+}
+
+// BatchCall is a typed call of a batch request. after CallBatch returns, Response
+// holds the response which has the same id and Err holds the error of this call
+// only, e.g. the response is missing or it can't be decoded.
+type BatchCall[T any] struct {
+	Method   string
+	Params   []any
+	Response JsonRpcResponse[T]
+	Err      error
+}
+
+func NewBatchCall[T any](method string, params ...any) *BatchCall[T] {
+	return &BatchCall[T]{
+		Method: method,
+		Params: params,
+	}
+}
+
+func (b *BatchCall[T]) params() []any {
+	return append([]any{b.Method}, b.Params...)
+}
+
+func (b *BatchCall[T]) setResponse(body []byte, err error) {
+	if err != nil {
+		b.Err = err
+		return
+	}
+	if err := json.Unmarshal(body, &b.Response); err != nil {
+		b.Err = fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+	}
+}
+
+func prepareBatchPayload(batch []Request) ([]byte, error) {
+	requests := make([]JsonRpcRequest, 0, len(batch))
+	for i, r := range batch {
+		requests = append(requests, JsonRpcRequest{
+			JsonRpc: "2.0",
+			Id:      uint64(i + 1),
+			Method:  r.Method,
+			Params:  r.Params,
+		})
+	}
+	return json.Marshal(requests)
+}
+
+// GetResult returns the result of the call, the error is set if the call failed
+// or the node returned a json rpc error
+func (b *BatchCall[T]) GetResult() (T, error) {
+	if b.Err != nil {
+		var output T
+		return output, b.Err
+	}
+	return b.Response.GetResult(), b.Response.GetError()
+}
+
+// CallBatch packs all calls into one json array request. each call gets a unique id
+// and the responses are matched back by id so the order of responses doesn't matter.
+// the returned error is only for the whole batch, check each call for its own result.
+func (c *RpcClient) CallBatch(ctx context.Context, calls ...BatchRequest) error {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	requests := make([]Request, 0, len(calls))
+	for _, call := range calls {
+		params := call.params()
+		requests = append(requests, Request{
+			Method: params[0].(string),
+			Params: params[1:],
+		})
+	}
+
+	body, err := c.chain(c.handle)(ctx, &Request{
+		Batch:  requests,
+		Header: http.Header{},
+		Query:  url.Values{},
+	})
+	if err != nil {
+		return fmt.Errorf("rpc: call error, err: %w, body: %v", err, string(body))
+	}
+
+	var rawResponses []json.RawMessage
+	if err := json.Unmarshal(body, &rawResponses); err != nil {
+		// the node replies a single error object if it rejects the whole batch
+		var res JsonRpcResponse[json.RawMessage]
+		if json.Unmarshal(body, &res) == nil && res.Error != nil {
+			return res.Error
+		}
+		return fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+	}
+
+	responses := make(map[uint64][]byte, len(rawResponses))
+	for _, raw := range rawResponses {
+		var res struct {
+			Id uint64 `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &res); err != nil {
+			continue
+		}
+		responses[res.Id] = raw
+	}
+
+	for i, call := range calls {
+		raw, ok := responses[uint64(i+1)]
+		if !ok {
+			call.setResponse(nil, ErrBatchResponseNotFound)
+			continue
+		}
+		call.setResponse(raw, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/internal/client_test"
+)
+
+func TestCallBatch(t *testing.T) {
+	client_test.TestAll(
+		t,
+		[]client_test.Param{
+			{
+				Name:         "out of order",
+				RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getBalance","params":["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"]},{"jsonrpc":"2.0","id":2,"method":"getSlot"}]`,
+				ResponseBody: `[{"jsonrpc":"2.0","result":100,"id":2},{"jsonrpc":"2.0","result":{"context":{"slot":77317717},"value":21474636000},"id":1}]`,
+				F: func(url string) (any, error) {
+					c := NewRpcClient(url)
+					balance := NewBatchCall[ValueWithContext[uint64]]("getBalance", "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+					slot := NewBatchCall[uint64]("getSlot")
+					err := c.CallBatch(context.Background(), balance, slot)
+					return []any{balance.Response, slot.Response}, err
+				},
+				ExpectedValue: []any{
+					JsonRpcResponse[ValueWithContext[uint64]]{
+						JsonRpc: "2.0",
+						Id:      1,
+						Result: ValueWithContext[uint64]{
+							Context: Context{Slot: 77317717},
+							Value:   21474636000,
+						},
+					},
+					JsonRpcResponse[uint64]{
+						JsonRpc: "2.0",
+						Id:      2,
+						Result:  100,
+					},
+				},
+				ExpectedError: nil,
+			},
+			{
+				Name:         "per call error",
+				RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getSlot"},{"jsonrpc":"2.0","id":2,"method":"getBalance","params":["abc"]},{"jsonrpc":"2.0","id":3,"method":"getSlot"}]`,
+				ResponseBody: `[{"jsonrpc":"2.0","result":100,"id":1},{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":2}]`,
+				F: func(url string) (any, error) {
+					c := NewRpcClient(url)
+					slot := NewBatchCall[uint64]("getSlot")
+					balance := NewBatchCall[ValueWithContext[uint64]]("getBalance", "abc")
+					missing := NewBatchCall[uint64]("getSlot")
+					err := c.CallBatch(context.Background(), slot, balance, missing)
+
+					slotResult, slotErr := slot.GetResult()
+					_, balanceErr := balance.GetResult()
+					_, missingErr := missing.GetResult()
+					return []any{slotResult, slotErr, balanceErr, missingErr}, err
+				},
+				ExpectedValue: []any{
+					uint64(100),
+					nil,
+					&JsonRpcError{Code: -32602, Message: "Invalid param: Invalid"},
+					ErrBatchResponseNotFound,
+				},
+				ExpectedError: nil,
+			},
+			{
+				Name:         "whole batch rejected",
+				RequestBody:  `[{"jsonrpc":"2.0","id":1,"method":"getSlot"}]`,
+				ResponseBody: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+				F: func(url string) (any, error) {
+					c := NewRpcClient(url)
+					return nil, c.CallBatch(context.Background(), NewBatchCall[uint64]("getSlot"))
+				},
+				ExpectedValue: nil,
+				ExpectedError: &JsonRpcError{Code: -32600, Message: "Invalid request"},
+			},
+		},
+	)
+}
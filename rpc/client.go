@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 const (
@@ -57,14 +58,40 @@ func (e *JsonRpcError) Error() string {
 	return fmt.Sprintf("failed to marshal JsonRpcError, err: %v, code: %v, message: %v, data: %v", err, e.Code, e.Message, e.Data)
 }
 
+// TransportError means the request didn't get a http response
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("failed to do request, err: %v", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusError means the http status code of the response is beyond 200~300
+type HTTPStatusError struct {
+	StatusCode int
+	Header     http.Header
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("get status code: %v", e.StatusCode)
+}
+
 type ValueWithContext[T any] struct {
 	Context Context `json:"context"`
 	Value   T       `json:"value"`
 }
 
 type RpcClient struct {
-	endpoint   string
-	httpClient *http.Client
+	endpoint    string
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+	pool        *Pool
+	middlewares []Middleware
 }
 
 func NewRpcClient(endpoint string) RpcClient { return New(WithEndpoint(endpoint)) }
@@ -86,23 +113,73 @@ func New(opts ...Option) RpcClient {
 
 // Call will return body of response. if http code beyond 200~300, the error also returns.
 func (c *RpcClient) Call(ctx context.Context, params ...any) ([]byte, error) {
+	return c.chain(c.handle)(ctx, &Request{
+		Method: params[0].(string),
+		Params: params[1:],
+		Header: http.Header{},
+		Query:  url.Values{},
+	})
+}
+
+// handle is the innermost handler of the middleware chain
+func (c *RpcClient) handle(ctx context.Context, r *Request) ([]byte, error) {
 	// prepare payload
-	j, err := preparePayload(params)
+	var j []byte
+	var err error
+	if r.Batch != nil {
+		j, err = prepareBatchPayload(r.Batch)
+	} else {
+		j, err = preparePayload(append([]any{r.Method}, r.Params...))
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare payload, err: %v", err)
 	}
 
+	return c.send(ctx, r, j)
+}
+
+// post sends the payload to the endpoint and returns body of response
+func (c *RpcClient) post(ctx context.Context, r *Request, j []byte) ([]byte, error) {
+	if c.pool != nil {
+		return c.pool.do(ctx, r.methods(), func(ctx context.Context, endpoint string) ([]byte, error) {
+			return c.postTo(ctx, endpoint, r, j)
+		})
+	}
+	return c.postTo(ctx, c.endpoint, r, j)
+}
+
+func (c *RpcClient) postTo(ctx context.Context, endpoint string, r *Request, j []byte) ([]byte, error) {
+	if len(r.Query) > 0 {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse endpoint, err: %v", err)
+		}
+		q := u.Query()
+		for k, vs := range r.Query {
+			for _, v := range vs {
+				q.Add(k, v)
+			}
+		}
+		u.RawQuery = q.Encode()
+		endpoint = u.String()
+	}
+
 	// prepare request
-	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(j))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(j))
 	if err != nil {
 		return nil, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
 	}
-	req.Header.Add("Content-Type", "application/json")
+	for k, vs := range r.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
 
 	// do request
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to do request, err: %v", err)
+		return nil, &TransportError{Err: err}
 	}
 	defer res.Body.Close()
 
@@ -114,7 +191,7 @@ func (c *RpcClient) Call(ctx context.Context, params ...any) ([]byte, error) {
 
 	// check response code
 	if res.StatusCode < 200 || res.StatusCode > 300 {
-		return body, fmt.Errorf("get status code: %v", res.StatusCode)
+		return body, &HTTPStatusError{StatusCode: res.StatusCode, Header: res.Header}
 	}
 
 	return body, nil
@@ -140,7 +217,7 @@ func call[T any](c *RpcClient, ctx context.Context, params ...any) (T, error) {
 	// rpc call
 	body, err := c.Call(ctx, params...)
 	if err != nil {
-		return output, fmt.Errorf("rpc: call error, err: %v, body: %v", err, string(body))
+		return output, fmt.Errorf("rpc: call error, err: %w, body: %v", err, string(body))
 	}
 
 	// transfer data
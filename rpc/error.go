@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// JSON-RPC error codes used by the solana rpc server
+const (
+	ErrorCodeBlockCleanedUp                           = -32001
+	ErrorCodeSendTransactionPreflightFailure          = -32002
+	ErrorCodeTransactionSignatureVerificationFailure  = -32003
+	ErrorCodeBlockNotAvailable                        = -32004
+	ErrorCodeNodeUnhealthy                            = -32005
+	ErrorCodeTransactionPrecompileVerificationFailure = -32006
+	ErrorCodeSlotSkipped                              = -32007
+	ErrorCodeNoSnapshot                               = -32008
+	ErrorCodeLongTermStorageSlotSkipped               = -32009
+	ErrorCodeKeyExcludedFromSecondaryIndex            = -32010
+	ErrorCodeTransactionHistoryNotAvailable           = -32011
+	ErrorCodeScanError                                = -32012
+	ErrorCodeTransactionSignatureLenMismatch          = -32013
+	ErrorCodeBlockStatusNotAvailableYet               = -32014
+	ErrorCodeUnsupportedTransactionVersion            = -32015
+	ErrorCodeMinContextSlotNotReached                 = -32016
+)
+
+// Unwrap returns the typed error of known error codes so that callers can use
+// errors.As, e.g. *SendTransactionPreflightFailureError. it returns nil for
+// unknown codes.
+func (e *JsonRpcError) Unwrap() error {
+	switch e.Code {
+	case ErrorCodeSendTransactionPreflightFailure:
+		preflightErr := &SendTransactionPreflightFailureError{Message: e.Message}
+		_ = decodeErrorData(e.Data, &preflightErr.Simulation)
+		if preflightErr.Simulation.Err == "BlockhashNotFound" {
+			return &BlockhashNotFoundError{Message: e.Message, err: preflightErr}
+		}
+		return preflightErr
+	case ErrorCodeNodeUnhealthy:
+		nodeErr := &NodeUnhealthyError{Message: e.Message}
+		var data struct {
+			NumSlotsBehind *uint64 `json:"numSlotsBehind"`
+		}
+		if decodeErrorData(e.Data, &data) == nil {
+			nodeErr.NumSlotsBehind = data.NumSlotsBehind
+		}
+		return nodeErr
+	case ErrorCodeUnsupportedTransactionVersion:
+		return &UnsupportedTransactionVersionError{Message: e.Message}
+	case ErrorCodeMinContextSlotNotReached:
+		slotErr := &MinContextSlotNotReachedError{Message: e.Message}
+		var data struct {
+			ContextSlot uint64 `json:"contextSlot"`
+		}
+		if decodeErrorData(e.Data, &data) == nil {
+			slotErr.ContextSlot = data.ContextSlot
+		}
+		return slotErr
+	}
+	if strings.Contains(strings.ToLower(e.Message), "blockhash not found") {
+		return &BlockhashNotFoundError{Message: e.Message}
+	}
+	return nil
+}
+
+func decodeErrorData(data any, v any) error {
+	if data == nil {
+		return errors.New("no data")
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// SendTransactionPreflightFailureError means the simulation before sending the
+// transaction failed. Simulation holds the logs and the transaction error.
+type SendTransactionPreflightFailureError struct {
+	Message    string
+	Simulation SimulateTransactionValue
+}
+
+func (e *SendTransactionPreflightFailureError) Error() string {
+	return e.Message
+}
+
+// BlockhashNotFoundError means the node doesn't know the recent blockhash of the
+// transaction, it is either expired or too new for the node
+type BlockhashNotFoundError struct {
+	Message string
+	err     error
+}
+
+func (e *BlockhashNotFoundError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the preflight failure if it is reported by a simulation
+func (e *BlockhashNotFoundError) Unwrap() error {
+	return e.err
+}
+
+// NodeUnhealthyError means the node is behind the cluster
+type NodeUnhealthyError struct {
+	Message string
+	// NumSlotsBehind is nil if the node doesn't know how far behind it is
+	NumSlotsBehind *uint64
+}
+
+func (e *NodeUnhealthyError) Error() string {
+	return e.Message
+}
+
+// UnsupportedTransactionVersionError means the transaction version is newer than
+// maxSupportedTransactionVersion of the request
+type UnsupportedTransactionVersionError struct {
+	Message string
+}
+
+func (e *UnsupportedTransactionVersionError) Error() string {
+	return e.Message
+}
+
+// MinContextSlotNotReachedError means the node hasn't reached minContextSlot of the request
+type MinContextSlotNotReachedError struct {
+	Message     string
+	ContextSlot uint64
+}
+
+func (e *MinContextSlotNotReachedError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeJsonRpcError(t *testing.T, s string) error {
+	var res JsonRpcResponse[any]
+	require.NoError(t, json.Unmarshal([]byte(s), &res))
+	return res.GetError()
+}
+
+func TestJsonRpcError_SendTransactionPreflightFailure(t *testing.T) {
+	err := decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32002,"message":"Transaction simulation failed: Error processing Instruction 1: custom program error: 0x1771","data":{"accounts":null,"err":{"InstructionError":[1,{"Custom":6001}]},"logs":["Program 11111111111111111111111111111111 invoke [1]","Program log: Error: slippage"],"returnData":null,"unitsConsumed":2366}},"id":1}`)
+
+	var preflightErr *SendTransactionPreflightFailureError
+	require.True(t, errors.As(err, &preflightErr))
+	assert.Equal(t, "Transaction simulation failed: Error processing Instruction 1: custom program error: 0x1771", preflightErr.Error())
+	assert.Equal(t, SimulateTransactionValue{
+		Err:          map[string]any{"InstructionError": []any{float64(1), map[string]any{"Custom": float64(6001)}}},
+		Logs:         []string{"Program 11111111111111111111111111111111 invoke [1]", "Program log: Error: slippage"},
+		UnitConsumed: pointer.Get[uint64](2366),
+	}, preflightErr.Simulation)
+
+	var blockhashNotFoundErr *BlockhashNotFoundError
+	assert.False(t, errors.As(err, &blockhashNotFoundErr))
+}
+
+func TestJsonRpcError_BlockhashNotFound(t *testing.T) {
+	err := decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32002,"message":"Transaction simulation failed: Blockhash not found","data":{"accounts":null,"err":"BlockhashNotFound","logs":[],"returnData":null,"unitsConsumed":0}},"id":1}`)
+
+	var blockhashNotFoundErr *BlockhashNotFoundError
+	require.True(t, errors.As(err, &blockhashNotFoundErr))
+
+	// it is still a preflight failure
+	var preflightErr *SendTransactionPreflightFailureError
+	require.True(t, errors.As(err, &preflightErr))
+	assert.Equal(t, "BlockhashNotFound", preflightErr.Simulation.Err)
+
+	err = decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32003,"message":"Blockhash not found"},"id":1}`)
+	require.True(t, errors.As(err, &blockhashNotFoundErr))
+	assert.False(t, errors.As(err, &preflightErr))
+}
+
+func TestJsonRpcError_NodeUnhealthy(t *testing.T) {
+	err := decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}},"id":1}`)
+	var nodeErr *NodeUnhealthyError
+	require.True(t, errors.As(err, &nodeErr))
+	assert.Equal(t, pointer.Get[uint64](42), nodeErr.NumSlotsBehind)
+
+	err = decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is unhealthy","data":{}},"id":1}`)
+	require.True(t, errors.As(err, &nodeErr))
+	assert.Nil(t, nodeErr.NumSlotsBehind)
+}
+
+func TestJsonRpcError_UnsupportedTransactionVersion(t *testing.T) {
+	err := decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32015,"message":"Transaction version (0) is not supported by the requesting client. Please try the request again with the following configuration parameter: \"maxSupportedTransactionVersion\": 0"},"id":1}`)
+	var versionErr *UnsupportedTransactionVersionError
+	assert.True(t, errors.As(err, &versionErr))
+}
+
+func TestJsonRpcError_MinContextSlotNotReached(t *testing.T) {
+	err := decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32016,"message":"Minimum context slot has not been reached","data":{"contextSlot":100}},"id":1}`)
+	var slotErr *MinContextSlotNotReachedError
+	require.True(t, errors.As(err, &slotErr))
+	assert.Equal(t, uint64(100), slotErr.ContextSlot)
+}
+
+func TestJsonRpcError_Unknown(t *testing.T) {
+	err := decodeJsonRpcError(t, `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":1}`)
+	assert.Nil(t, errors.Unwrap(err))
+	assert.Equal(t, `{"code":-32602,"message":"Invalid param: Invalid","data":null}`, err.Error())
+}
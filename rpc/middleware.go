@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Request is a json rpc call seen by middlewares. middlewares can change it
+// before passing it to the next handler.
+type Request struct {
+	Method string
+	Params []any
+	// Batch holds every call of a batch request, Method and Params are empty then
+	Batch []Request
+	// Header is added to the http request, e.g. auth headers
+	Header http.Header
+	// Query is added to the url of the endpoint, e.g. api key tokens
+	Query url.Values
+}
+
+func (r *Request) methods() []string {
+	if r.Batch == nil {
+		return []string{r.Method}
+	}
+	methods := make([]string, 0, len(r.Batch))
+	for _, b := range r.Batch {
+		methods = append(methods, b.Method)
+	}
+	return methods
+}
+
+// Handler sends the request and returns the raw response body
+type Handler func(ctx context.Context, req *Request) ([]byte, error)
+
+// Middleware wraps a handler. it can inspect or change the request, the raw
+// response and the error, and measure the latency of next.
+type Middleware func(next Handler) Handler
+
+// chain wraps h with middlewares, the first middleware is the outermost one
+func (c *RpcClient) chain(h Handler) Handler {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+	return h
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var gotHeader, gotQuery, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotHeader = req.Header.Get("Authorization")
+		gotQuery = req.URL.Query().Get("api-key")
+		b, _ := io.ReadAll(req.Body)
+		gotBody = string(b)
+		_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":100,"id":1}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) ([]byte, error) {
+				order = append(order, name+" before "+req.Method)
+				body, err := next(ctx, req)
+				order = append(order, name+" after")
+				return body, err
+			}
+		}
+	}
+	auth := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			req.Header.Set("Authorization", "Bearer token")
+			req.Query.Set("api-key", "key")
+			return next(ctx, req)
+		}
+	}
+	var latency time.Duration
+	var rawResponse string
+	metrics := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			start := time.Now()
+			body, err := next(ctx, req)
+			latency = time.Since(start)
+			rawResponse = string(body)
+			return body, err
+		}
+	}
+
+	c := New(WithEndpoint(server.URL), WithMiddleware(trace("a"), trace("b")), WithMiddleware(auth, metrics))
+	res, err := c.GetSlotWithConfig(context.Background(), GetSlotConfig{Commitment: CommitmentConfirmed})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), res.Result)
+
+	assert.Equal(t, []string{"a before getSlot", "b before getSlot", "b after", "a after"}, order)
+	assert.Equal(t, "Bearer token", gotHeader)
+	assert.Equal(t, "key", gotQuery)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"method":"getSlot","params":[{"commitment":"confirmed"}]}`, gotBody)
+	assert.Equal(t, `{"jsonrpc":"2.0","result":100,"id":1}`, rawResponse)
+	assert.Greater(t, latency, time.Duration(0))
+}
+
+func TestWithMiddleware_RewriteResponse(t *testing.T) {
+	stub := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			switch req.Method {
+			case "getSlot":
+				return []byte(`{"jsonrpc":"2.0","result":42,"id":1}`), nil
+			case "":
+				return []byte(`[{"jsonrpc":"2.0","result":43,"id":1}]`), nil
+			}
+			return nil, errors.New("unexpected method")
+		}
+	}
+
+	// no server is needed since the middleware never calls next
+	c := New(WithEndpoint("http://127.0.0.1:0"), WithMiddleware(stub))
+
+	res, err := c.GetSlot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), res.Result)
+
+	call := NewBatchCall[uint64]("getSlot")
+	require.NoError(t, c.CallBatch(context.Background(), call))
+	assert.Equal(t, uint64(43), call.Response.Result)
+
+	_, err = c.GetHealth(context.Background())
+	assert.EqualError(t, err, "rpc: call error, err: unexpected method, body: ")
+}
@@ -22,6 +22,30 @@ func WithEndpoint(endpoint string) Option {
 	}
 }
 
+// WithRetry is an Option that retries failed calls by the policy. sendTransaction
+// is never retried unless the policy opts in by RetryNonIdempotent.
+func WithRetry(policy RetryPolicy) Option {
+	return func(r *RpcClient) {
+		r.retryPolicy = &policy
+	}
+}
+
+// WithPool is an Option that sends every call through the endpoint pool instead
+// of the single endpoint
+func WithPool(p *Pool) Option {
+	return func(r *RpcClient) {
+		r.pool = p
+	}
+}
+
+// WithMiddleware is an Option that wraps every call by the middlewares. they
+// compose in order, the first one sees the request first and the response last.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(r *RpcClient) {
+		r.middlewares = append(r.middlewares, middlewares...)
+	}
+}
+
 func setDefaultOptions(r *RpcClient) {
 	r.httpClient = &http.Client{}
 	r.endpoint = MainnetRPCEndpoint
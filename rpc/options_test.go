@@ -27,3 +27,21 @@ func TestOption_WithEndpoint(t *testing.T) {
 
 	require.Equal(t, endpoint, c.endpoint)
 }
+
+func TestOption_WithRetry(t *testing.T) {
+
+	policy := DefaultRetryPolicy()
+
+	c := New(WithRetry(policy))
+
+	require.Equal(t, &policy, c.retryPolicy)
+}
+
+func TestOption_WithMiddleware(t *testing.T) {
+
+	m := func(next Handler) Handler { return next }
+
+	c := New(WithMiddleware(m, m), WithMiddleware(m))
+
+	require.Len(t, c.middlewares, 3)
+}
@@ -0,0 +1,369 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrNoEndpointAvailable = errors.New("rpc: no endpoint available")
+
+// EndpointRole decides which calls an endpoint serves
+type EndpointRole uint8
+
+const (
+	// EndpointRoleRead serves every call except sendTransaction
+	EndpointRoleRead EndpointRole = 1 << iota
+	// EndpointRoleSend serves sendTransaction
+	EndpointRoleSend
+
+	EndpointRoleAll = EndpointRoleRead | EndpointRoleSend
+)
+
+type PoolEndpoint struct {
+	Endpoint string
+	// Weight is the relative share of calls, default: 1
+	Weight int
+	// Role default: EndpointRoleAll
+	Role EndpointRole
+}
+
+// EndpointStatus is the latest health check result of an endpoint
+type EndpointStatus struct {
+	Endpoint  string
+	Healthy   bool
+	Slot      uint64
+	LastError error
+	CheckedAt time.Time
+}
+
+// Pool spreads calls over several endpoints. it takes an endpoint out of rotation
+// if getHealth fails, its slot lags behind the others or a call to it fails, and
+// it fails over to the next endpoint on transport errors, http 429/5xx and node
+// behind errors.
+type Pool struct {
+	endpoints           []*poolEndpoint
+	httpClient          *http.Client
+	healthCheckInterval time.Duration
+	maxSlotLag          uint64
+	hedgeDelay          time.Duration
+}
+
+type poolEndpoint struct {
+	PoolEndpoint
+
+	mu     sync.RWMutex
+	status EndpointStatus
+}
+
+func (e *poolEndpoint) healthy() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status.Healthy
+}
+
+func (e *poolEndpoint) markFailure(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status.Healthy = false
+	e.status.LastError = err
+}
+
+// PoolOption is a configuration type for the Pool
+type PoolOption func(*Pool)
+
+// WithPoolHTTPClient sets the http client used by health checks
+func WithPoolHTTPClient(h *http.Client) PoolOption {
+	return func(p *Pool) {
+		p.httpClient = h
+	}
+}
+
+// WithHealthCheckInterval sets how often Run checks every endpoint
+func WithHealthCheckInterval(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.healthCheckInterval = d
+	}
+}
+
+// WithMaxSlotLag takes an endpoint out of rotation if its slot is more than n
+// slots behind the highest slot among endpoints
+func WithMaxSlotLag(n uint64) PoolOption {
+	return func(p *Pool) {
+		p.maxSlotLag = n
+	}
+}
+
+// WithHedgeDelay sends a read call to a second endpoint as well if the first one
+// doesn't respond within d, the first successful response wins. zero disables it.
+func WithHedgeDelay(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.hedgeDelay = d
+	}
+}
+
+func NewPool(endpoints []PoolEndpoint, opts ...PoolOption) *Pool {
+	p := &Pool{
+		httpClient:          &http.Client{},
+		healthCheckInterval: 10 * time.Second,
+		maxSlotLag:          50,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	for _, e := range endpoints {
+		if e.Weight <= 0 {
+			e.Weight = 1
+		}
+		if e.Role == 0 {
+			e.Role = EndpointRoleAll
+		}
+		p.endpoints = append(p.endpoints, &poolEndpoint{
+			PoolEndpoint: e,
+			// endpoints are in rotation until the first health check says otherwise
+			status: EndpointStatus{Endpoint: e.Endpoint, Healthy: true},
+		})
+	}
+	return p
+}
+
+// Run checks endpoints periodically until ctx is done
+func (p *Pool) Run(ctx context.Context) {
+	t := time.NewTicker(p.healthCheckInterval)
+	defer t.Stop()
+	for {
+		p.CheckHealth(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// CheckHealth calls getHealth and getSlot on every endpoint once
+func (p *Pool) CheckHealth(ctx context.Context) {
+	results := make([]EndpointStatus, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *poolEndpoint) {
+			defer wg.Done()
+			results[i] = p.check(ctx, e.Endpoint)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var maxSlot uint64
+	for _, r := range results {
+		if r.Healthy && r.Slot > maxSlot {
+			maxSlot = r.Slot
+		}
+	}
+	for i, e := range p.endpoints {
+		r := results[i]
+		if r.Healthy && maxSlot-r.Slot > p.maxSlotLag {
+			r.Healthy = false
+		}
+		e.mu.Lock()
+		e.status = r
+		e.mu.Unlock()
+	}
+}
+
+func (p *Pool) check(ctx context.Context, endpoint string) EndpointStatus {
+	status := EndpointStatus{Endpoint: endpoint, CheckedAt: time.Now()}
+	c := New(WithEndpoint(endpoint), WithHTTPClient(p.httpClient))
+
+	health, err := c.GetHealth(ctx)
+	if err == nil {
+		err = health.GetError()
+	}
+	if err != nil {
+		status.LastError = err
+		return status
+	}
+
+	slot, err := c.GetSlot(ctx)
+	if err == nil {
+		err = slot.GetError()
+	}
+	if err != nil {
+		status.LastError = err
+		return status
+	}
+
+	status.Healthy = true
+	status.Slot = slot.Result
+	return status
+}
+
+// Status returns the latest status of every endpoint
+func (p *Pool) Status() []EndpointStatus {
+	output := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		e.mu.RLock()
+		output = append(output, e.status)
+		e.mu.RUnlock()
+	}
+	return output
+}
+
+// candidates returns endpoints of the role in the order they should be tried.
+// healthy endpoints come first in a weighted random order, the rest are kept
+// as the last resort.
+func (p *Pool) candidates(role EndpointRole) []*poolEndpoint {
+	var healthy, unhealthy []*poolEndpoint
+	for _, e := range p.endpoints {
+		if e.Role&role == 0 {
+			continue
+		}
+		if e.healthy() {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	ordered := make([]*poolEndpoint, 0, len(healthy)+len(unhealthy))
+	for len(healthy) > 0 {
+		total := 0
+		for _, e := range healthy {
+			total += e.Weight
+		}
+		n := rand.Intn(total)
+		for i, e := range healthy {
+			if n < e.Weight {
+				ordered = append(ordered, e)
+				healthy = append(healthy[:i], healthy[i+1:]...)
+				break
+			}
+			n -= e.Weight
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i].Weight > unhealthy[j].Weight
+	})
+	return append(ordered, unhealthy...)
+}
+
+// failureError returns the error a failed call is recorded with, a JSON-RPC error
+// comes in the body while err is nil
+func failureError(body []byte, err error) error {
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Error *JsonRpcError `json:"error"`
+	}
+	if json.Unmarshal(body, &res) == nil && res.Error != nil {
+		return res.Error
+	}
+	return fmt.Errorf("rpc: unexpected response, body: %v", string(body))
+}
+
+func shouldFailover(class ErrorClass) bool {
+	switch class {
+	case ErrorClassTransport, ErrorClassRateLimited, ErrorClassServer, ErrorClassNodeBehind, ErrorClassBlockhashNotFound:
+		return true
+	}
+	return false
+}
+
+type poolResult struct {
+	endpoint *poolEndpoint
+	body     []byte
+	err      error
+}
+
+func (p *Pool) do(ctx context.Context, methods []string, post func(context.Context, string) ([]byte, error)) ([]byte, error) {
+	role := EndpointRoleRead
+	for _, method := range methods {
+		if method == "sendTransaction" {
+			role = EndpointRoleSend
+		}
+	}
+
+	candidates := p.candidates(role)
+	if len(candidates) == 0 {
+		return nil, ErrNoEndpointAvailable
+	}
+
+	var last poolResult
+	for i := 0; i < len(candidates); {
+		n := 1
+		if role == EndpointRoleRead && p.hedgeDelay > 0 && i+1 < len(candidates) {
+			n = 2
+		}
+		r, ok := p.attempt(ctx, candidates[i:i+n], post)
+		if ok {
+			return r.body, r.err
+		}
+		last = r
+		if ctx.Err() != nil {
+			break
+		}
+		i += n
+	}
+	return last.body, last.err
+}
+
+// attempt sends the call to the first endpoint, and to the second one as well if
+// the first doesn't respond within the hedge delay. it returns false if every
+// endpoint failed with an error worth failing over.
+func (p *Pool) attempt(ctx context.Context, endpoints []*poolEndpoint, post func(context.Context, string) ([]byte, error)) (poolResult, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan poolResult, len(endpoints))
+	start := func(e *poolEndpoint) {
+		go func() {
+			body, err := post(ctx, e.Endpoint)
+			results <- poolResult{endpoint: e, body: body, err: err}
+		}()
+	}
+
+	start(endpoints[0])
+	started := 1
+
+	var hedge <-chan time.Time
+	if len(endpoints) > 1 {
+		t := time.NewTimer(p.hedgeDelay)
+		defer t.Stop()
+		hedge = t.C
+	}
+
+	var last poolResult
+	for finished := 0; finished < started; {
+		select {
+		case <-hedge:
+			start(endpoints[1])
+			started++
+			hedge = nil
+		case r := <-results:
+			finished++
+			if class := ClassifyError(r.body, r.err); shouldFailover(class) {
+				// the caller giving up says nothing about the endpoint
+				if ctx.Err() == nil {
+					r.endpoint.markFailure(failureError(r.body, r.err))
+				}
+				last = r
+				// don't wait for the hedge delay if the first one failed already
+				if hedge != nil {
+					start(endpoints[1])
+					started++
+					hedge = nil
+				}
+				continue
+			}
+			return r, true
+		}
+	}
+	return last, false
+}
@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type poolTestNode struct {
+	*httptest.Server
+	slot   uint64
+	status int
+	delay  time.Duration
+	calls  map[string]*int32
+	// rpcErr is returned as the JSON-RPC error of every call if it's set
+	rpcErr *JsonRpcError
+}
+
+func newPoolTestNode(t *testing.T, slot uint64) *poolTestNode {
+	n := &poolTestNode{slot: slot, status: http.StatusOK, calls: map[string]*int32{}}
+	for _, method := range []string{"getHealth", "getSlot", "getBalance", "sendTransaction"} {
+		n.calls[method] = new(int32)
+	}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var r JsonRpcRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&r))
+		atomic.AddInt32(n.calls[r.Method], 1)
+
+		time.Sleep(n.delay)
+		if n.status != http.StatusOK {
+			rw.WriteHeader(n.status)
+			return
+		}
+
+		if n.rpcErr != nil {
+			_ = json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": r.Id, "error": n.rpcErr})
+			return
+		}
+
+		var result any
+		switch r.Method {
+		case "getHealth":
+			result = "ok"
+		case "getSlot":
+			result = n.slot
+		case "getBalance":
+			result = map[string]any{"context": map[string]any{"slot": n.slot}, "value": n.slot}
+		case "sendTransaction":
+			result = "sig"
+		}
+		_ = json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": r.Id, "result": result})
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+func (n *poolTestNode) count(method string) int32 {
+	return atomic.LoadInt32(n.calls[method])
+}
+
+func TestPool_Failover(t *testing.T) {
+	down := newPoolTestNode(t, 100)
+	down.status = http.StatusServiceUnavailable
+	up := newPoolTestNode(t, 200)
+
+	pool := NewPool([]PoolEndpoint{{Endpoint: down.URL}, {Endpoint: up.URL}})
+	c := New(WithPool(pool))
+
+	for i := 0; i < 5; i++ {
+		res, err := c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(200), res.Result.Value)
+	}
+	// the failed endpoint is out of rotation after its first failure
+	assert.Equal(t, int32(1), down.count("getBalance"))
+	assert.False(t, pool.Status()[0].Healthy)
+}
+
+func TestPool_FailoverOnJsonRpcError(t *testing.T) {
+	behind := newPoolTestNode(t, 100)
+	behind.rpcErr = &JsonRpcError{Code: ErrorCodeNodeUnhealthy, Message: "Node is behind by 42 slots"}
+	up := newPoolTestNode(t, 200)
+
+	pool := NewPool([]PoolEndpoint{{Endpoint: behind.URL, Weight: 100}, {Endpoint: up.URL}})
+	c := New(WithPool(pool))
+
+	res, err := c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), res.Result.Value)
+
+	status := pool.Status()[0]
+	assert.False(t, status.Healthy)
+	var nodeErr *NodeUnhealthyError
+	assert.ErrorAs(t, status.LastError, &nodeErr)
+}
+
+// stuckTransport ignores the request context like a dialer stuck in the kernel
+// and fails with a plain network error once the caller has given up
+type stuckTransport struct {
+	delay time.Duration
+}
+
+func (t stuckTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	time.Sleep(t.delay)
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestPool_CallerCancel(t *testing.T) {
+	node := newPoolTestNode(t, 100)
+
+	pool := NewPool([]PoolEndpoint{{Endpoint: node.URL}})
+	c := New(WithPool(pool), WithHTTPClient(&http.Client{Transport: stuckTransport{delay: 100 * time.Millisecond}}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.GetBalance(ctx, "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	assert.Error(t, err)
+
+	// the endpoint stays in rotation
+	status := pool.Status()[0]
+	assert.True(t, status.Healthy)
+	assert.NoError(t, status.LastError)
+}
+
+func TestPool_CheckHealth(t *testing.T) {
+	lagging := newPoolTestNode(t, 100)
+	latest := newPoolTestNode(t, 200)
+
+	pool := NewPool(
+		[]PoolEndpoint{{Endpoint: lagging.URL, Weight: 100}, {Endpoint: latest.URL}},
+		WithMaxSlotLag(10),
+	)
+	pool.CheckHealth(context.Background())
+
+	status := pool.Status()
+	assert.False(t, status[0].Healthy)
+	assert.Equal(t, uint64(100), status[0].Slot)
+	assert.True(t, status[1].Healthy)
+	assert.Equal(t, uint64(200), status[1].Slot)
+
+	c := New(WithPool(pool))
+	for i := 0; i < 5; i++ {
+		res, err := c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(200), res.Result.Value)
+	}
+	assert.Equal(t, int32(0), lagging.count("getBalance"))
+}
+
+func TestPool_Role(t *testing.T) {
+	reader := newPoolTestNode(t, 100)
+	sender := newPoolTestNode(t, 100)
+
+	pool := NewPool([]PoolEndpoint{
+		{Endpoint: reader.URL, Role: EndpointRoleRead},
+		{Endpoint: sender.URL, Role: EndpointRoleSend},
+	})
+	c := New(WithPool(pool))
+
+	_, err := c.SendTransaction(context.Background(), "tx")
+	require.NoError(t, err)
+	_, err = c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(0), reader.count("sendTransaction"))
+	assert.Equal(t, int32(1), reader.count("getBalance"))
+	assert.Equal(t, int32(1), sender.count("sendTransaction"))
+	assert.Equal(t, int32(0), sender.count("getBalance"))
+}
+
+func TestPool_Hedge(t *testing.T) {
+	slow := newPoolTestNode(t, 100)
+	slow.delay = time.Second
+	fast := newPoolTestNode(t, 200)
+
+	pool := NewPool(
+		[]PoolEndpoint{{Endpoint: slow.URL}, {Endpoint: fast.URL}},
+		WithHedgeDelay(10*time.Millisecond),
+	)
+	c := New(WithPool(pool))
+
+	start := time.Now()
+	res, err := c.GetBalance(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), res.Result.Value)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestPool_NoEndpoint(t *testing.T) {
+	reader := newPoolTestNode(t, 100)
+	c := New(WithPool(NewPool([]PoolEndpoint{{Endpoint: reader.URL, Role: EndpointRoleRead}})))
+
+	_, err := c.SendTransaction(context.Background(), "tx")
+	assert.ErrorIs(t, err, ErrNoEndpointAvailable)
+}
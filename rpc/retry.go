@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrorClass describes why a call failed, it decides whether the call is retried
+type ErrorClass int
+
+const (
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassTransport means no http response was received, e.g. connection reset
+	ErrorClassTransport
+	// ErrorClassRateLimited means the endpoint replied http 429
+	ErrorClassRateLimited
+	// ErrorClassServer means the endpoint replied http 5xx
+	ErrorClassServer
+	// ErrorClassNodeBehind means the node is unhealthy or behind the cluster (-32005)
+	ErrorClassNodeBehind
+	// ErrorClassBlockhashNotFound means the node hasn't seen the blockhash yet
+	ErrorClassBlockhashNotFound
+	// ErrorClassOther is every other failure, it is not retried by default
+	ErrorClassOther
+)
+
+// nonIdempotentMethods are not retried unless RetryPolicy.RetryNonIdempotent is set
+var nonIdempotentMethods = map[string]bool{
+	"sendTransaction": true,
+	"requestAirdrop":  true,
+}
+
+// RetryPolicy configures how failed calls are retried with exponential backoff
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, 1 disables retry
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every attempt
+	Multiplier float64
+	// Jitter randomizes the delay by ±Jitter (0~1) of itself
+	Jitter float64
+	// RetryNonIdempotent allows retrying sendTransaction and requestAirdrop
+	RetryNonIdempotent bool
+	// ShouldRetry overrides the default decision for each failure if it is set
+	ShouldRetry func(method string, class ErrorClass) bool
+}
+
+// DefaultRetryPolicy retries transport errors, http 429/5xx, node behind and
+// blockhash not found up to 3 times
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// ClassifyError classifies the result of an rpc call
+func ClassifyError(body []byte, err error) ErrorClass {
+	if err != nil {
+		var httpErr *HTTPStatusError
+		if errors.As(err, &httpErr) {
+			switch {
+			case httpErr.StatusCode == http.StatusTooManyRequests:
+				return ErrorClassRateLimited
+			case httpErr.StatusCode >= 500:
+				return ErrorClassServer
+			}
+			return ErrorClassOther
+		}
+		var transportErr *TransportError
+		if errors.As(err, &transportErr) {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return ErrorClassOther
+			}
+			return ErrorClassTransport
+		}
+		return ErrorClassOther
+	}
+
+	var res struct {
+		Error *JsonRpcError `json:"error"`
+	}
+	if len(body) == 0 || body[0] != '{' || json.Unmarshal(body, &res) != nil || res.Error == nil {
+		return ErrorClassNone
+	}
+	var nodeUnhealthyErr *NodeUnhealthyError
+	if errors.As(res.Error, &nodeUnhealthyErr) {
+		return ErrorClassNodeBehind
+	}
+	var blockhashNotFoundErr *BlockhashNotFoundError
+	if errors.As(res.Error, &blockhashNotFoundErr) {
+		return ErrorClassBlockhashNotFound
+	}
+	return ErrorClassOther
+}
+
+func (p RetryPolicy) shouldRetry(methods []string, class ErrorClass) bool {
+	if class == ErrorClassNone {
+		return false
+	}
+	for _, method := range methods {
+		if nonIdempotentMethods[method] && !p.RetryNonIdempotent {
+			return false
+		}
+	}
+	if p.ShouldRetry != nil {
+		for _, method := range methods {
+			if !p.ShouldRetry(method, class) {
+				return false
+			}
+		}
+		return true
+	}
+	return class != ErrorClassOther
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+		if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// retryAfter parses the Retry-After header, either seconds or a http date
+func retryAfter(err error) (time.Duration, bool) {
+	var httpErr *HTTPStatusError
+	if !errors.As(err, &httpErr) || httpErr.Header == nil {
+		return 0, false
+	}
+	v := httpErr.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// send posts the payload and retries it by the retry policy
+func (c *RpcClient) send(ctx context.Context, r *Request, j []byte) ([]byte, error) {
+	if c.retryPolicy == nil {
+		return c.post(ctx, r, j)
+	}
+	p := *c.retryPolicy
+	methods := r.methods()
+
+	for attempt := 1; ; attempt++ {
+		body, err := c.post(ctx, r, j)
+		class := ClassifyError(body, err)
+		if attempt >= p.MaxAttempts || !p.shouldRetry(methods, class) {
+			return body, err
+		}
+
+		wait := p.backoff(attempt)
+		if d, ok := retryAfter(err); ok {
+			wait = d
+		}
+		// give up early if the next attempt can't finish before the deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return body, err
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return body, err
+		case <-t.C:
+		}
+	}
+}
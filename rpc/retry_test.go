@@ -0,0 +1,208 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		want ErrorClass
+	}{
+		{
+			name: "ok",
+			body: `{"jsonrpc":"2.0","result":1,"id":1}`,
+			want: ErrorClassNone,
+		},
+		{
+			name: "transport",
+			err:  &TransportError{Err: errors.New("connection reset by peer")},
+			want: ErrorClassTransport,
+		},
+		{
+			name: "context canceled",
+			err:  &TransportError{Err: context.Canceled},
+			want: ErrorClassOther,
+		},
+		{
+			name: "429",
+			err:  &HTTPStatusError{StatusCode: 429},
+			want: ErrorClassRateLimited,
+		},
+		{
+			name: "503",
+			err:  &HTTPStatusError{StatusCode: 503},
+			want: ErrorClassServer,
+		},
+		{
+			name: "403",
+			err:  &HTTPStatusError{StatusCode: 403},
+			want: ErrorClassOther,
+		},
+		{
+			name: "node behind",
+			body: `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}},"id":1}`,
+			want: ErrorClassNodeBehind,
+		},
+		{
+			name: "blockhash not found",
+			body: `{"jsonrpc":"2.0","error":{"code":-32002,"message":"Transaction simulation failed: Blockhash not found","data":{"accounts":null,"err":"BlockhashNotFound","logs":[],"unitsConsumed":0}},"id":1}`,
+			want: ErrorClassBlockhashNotFound,
+		},
+		{
+			name: "invalid param",
+			body: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":1}`,
+			want: ErrorClassOther,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError([]byte(tt.body), tt.err))
+		})
+	}
+}
+
+func newRetryTestServer(responses []func(rw http.ResponseWriter)) (*httptest.Server, *int32) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(&count, 1)
+		responses[int(n-1)%len(responses)](rw)
+	}))
+	return server, &count
+}
+
+func status(code int, header map[string]string) func(rw http.ResponseWriter) {
+	return func(rw http.ResponseWriter) {
+		for k, v := range header {
+			rw.Header().Set(k, v)
+		}
+		rw.WriteHeader(code)
+	}
+}
+
+func body(s string) func(rw http.ResponseWriter) {
+	return func(rw http.ResponseWriter) {
+		_, _ = rw.Write([]byte(s))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	t.Run("retry 429 and 5xx", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			status(429, map[string]string{"Retry-After": "0"}),
+			status(502, nil),
+			body(`{"jsonrpc":"2.0","result":100,"id":1}`),
+		})
+		defer server.Close()
+
+		c := New(WithEndpoint(server.URL), WithRetry(policy))
+		res, err := c.GetSlot(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100), res.Result)
+		assert.Equal(t, int32(3), atomic.LoadInt32(count))
+	})
+
+	t.Run("retry node behind", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			body(`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is unhealthy"},"id":1}`),
+			body(`{"jsonrpc":"2.0","result":100,"id":1}`),
+		})
+		defer server.Close()
+
+		c := New(WithEndpoint(server.URL), WithRetry(policy))
+		res, err := c.GetSlot(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100), res.Result)
+		assert.Equal(t, int32(2), atomic.LoadInt32(count))
+	})
+
+	t.Run("give up after max attempts", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			status(503, nil),
+		})
+		defer server.Close()
+
+		c := New(WithEndpoint(server.URL), WithRetry(policy))
+		_, err := c.GetSlot(context.Background())
+		var httpErr *HTTPStatusError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, int32(3), atomic.LoadInt32(count))
+	})
+
+	t.Run("not retry non retryable error", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			body(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":1}`),
+		})
+		defer server.Close()
+
+		c := New(WithEndpoint(server.URL), WithRetry(policy))
+		res, err := c.GetBalance(context.Background(), "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, &JsonRpcError{Code: -32602, Message: "Invalid param: Invalid"}, res.Error)
+		assert.Equal(t, int32(1), atomic.LoadInt32(count))
+	})
+
+	t.Run("not retry sendTransaction by default", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			status(503, nil),
+			body(`{"jsonrpc":"2.0","result":"sig","id":1}`),
+		})
+		defer server.Close()
+
+		c := New(WithEndpoint(server.URL), WithRetry(policy))
+		_, err := c.SendTransaction(context.Background(), "tx")
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(count))
+	})
+
+	t.Run("retry sendTransaction if opt in", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			status(503, nil),
+			body(`{"jsonrpc":"2.0","result":"sig","id":1}`),
+		})
+		defer server.Close()
+
+		p := policy
+		p.RetryNonIdempotent = true
+		c := New(WithEndpoint(server.URL), WithRetry(p))
+		res, err := c.SendTransaction(context.Background(), "tx")
+		assert.NoError(t, err)
+		assert.Equal(t, "sig", res.Result)
+		assert.Equal(t, int32(2), atomic.LoadInt32(count))
+	})
+
+	t.Run("respect context deadline", func(t *testing.T) {
+		server, count := newRetryTestServer([]func(http.ResponseWriter){
+			status(429, map[string]string{"Retry-After": "10"}),
+			body(`{"jsonrpc":"2.0","result":100,"id":1}`),
+		})
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		c := New(WithEndpoint(server.URL), WithRetry(policy))
+		_, err := c.GetSlot(ctx)
+		var httpErr *HTTPStatusError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 429, httpErr.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(count))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 
@@ -105,18 +106,153 @@ func (m *Message) Serialize() ([]byte, error) {
 	return b, nil
 }
 
-// DecompileInstructions hasn't support v0 message decode
+// ErrUnsupportedMessageVersion means the message is neither a legacy nor a v0 message
+var ErrUnsupportedMessageVersion = errors.New("unsupported message version")
+
+// DecompileInstructions panics if the message is a v0 message which loads addresses
+// from lookup tables, use DecompileInstructionsWithAddressLookupTables or
+// DecompileInstructionsWithLoadedAddresses for it. they return errors instead of
+// panicking and reject an unsupported version.
 func (m *Message) DecompileInstructions() []Instruction {
 	switch m.Version {
 	case MessageVersionLegacy:
 		return m.decompileLegacyMessageInstructions()
 	case MessageVersionV0:
-		panic("hasn't supported")
+		for _, lookup := range m.AddressLookupTables {
+			if len(lookup.WritableIndexes)+len(lookup.ReadonlyIndexes) > 0 {
+				panic("v0 message which loads addresses from lookup tables needs the loaded addresses to decompile")
+			}
+		}
+		return m.decompileLegacyMessageInstructions()
 	default:
 		return m.decompileLegacyMessageInstructions()
 	}
 }
 
+// checkVersion accepts legacy and v0 messages, an empty version is legacy
+func (m *Message) checkVersion() error {
+	switch m.Version {
+	case "", MessageVersionLegacy, MessageVersionV0:
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnsupportedMessageVersion, m.Version)
+}
+
+// LoadedAddresses are addresses a v0 message loads from address lookup tables. the
+// account index of a loaded address follows static accounts, writable ones first.
+type LoadedAddresses struct {
+	Writable []common.PublicKey
+	Readonly []common.PublicKey
+}
+
+// ResolveAddressLookupTables looks up addresses the message uses in the tables
+func (m *Message) ResolveAddressLookupTables(tables []AddressLookupTableAccount) (LoadedAddresses, error) {
+	addresses := make(map[common.PublicKey][]common.PublicKey, len(tables))
+	for _, table := range tables {
+		addresses[table.Key] = table.Addresses
+	}
+
+	var loaded LoadedAddresses
+	for _, lookup := range m.AddressLookupTables {
+		tableAddresses, ok := addresses[lookup.AccountKey]
+		if !ok {
+			return LoadedAddresses{}, fmt.Errorf("address lookup table %v not found", lookup.AccountKey.ToBase58())
+		}
+		for _, idx := range lookup.WritableIndexes {
+			if int(idx) >= len(tableAddresses) {
+				return LoadedAddresses{}, fmt.Errorf("index %v is out of range of address lookup table %v", idx, lookup.AccountKey.ToBase58())
+			}
+			loaded.Writable = append(loaded.Writable, tableAddresses[idx])
+		}
+		for _, idx := range lookup.ReadonlyIndexes {
+			if int(idx) >= len(tableAddresses) {
+				return LoadedAddresses{}, fmt.Errorf("index %v is out of range of address lookup table %v", idx, lookup.AccountKey.ToBase58())
+			}
+			loaded.Readonly = append(loaded.Readonly, tableAddresses[idx])
+		}
+	}
+	return loaded, nil
+}
+
+// DecompileInstructionsWithAddressLookupTables decompiles a legacy or v0 message, the
+// tables should contain every table the message uses
+func (m *Message) DecompileInstructionsWithAddressLookupTables(tables []AddressLookupTableAccount) ([]Instruction, error) {
+	loaded, err := m.ResolveAddressLookupTables(tables)
+	if err != nil {
+		return nil, err
+	}
+	return m.DecompileInstructionsWithLoadedAddresses(loaded)
+}
+
+// DecompileInstructionsWithLoadedAddresses decompiles a legacy or v0 message with the
+// loaded addresses, e.g. LoadedAddresses of the transaction meta
+func (m *Message) DecompileInstructionsWithLoadedAddresses(loaded LoadedAddresses) ([]Instruction, error) {
+	if err := m.checkVersion(); err != nil {
+		return nil, err
+	}
+	numLoaded := len(loaded.Writable) + len(loaded.Readonly)
+	if m.Version != MessageVersionV0 && numLoaded > 0 {
+		return nil, errors.New("legacy message can't load addresses")
+	}
+	expected := 0
+	for _, lookup := range m.AddressLookupTables {
+		expected += len(lookup.WritableIndexes) + len(lookup.ReadonlyIndexes)
+	}
+	if numLoaded != expected {
+		return nil, fmt.Errorf("message loads %v addresses but got %v", expected, numLoaded)
+	}
+	if int(m.Header.NumRequireSignatures) > len(m.Accounts) ||
+		m.Header.NumReadonlySignedAccounts > m.Header.NumRequireSignatures ||
+		int(m.Header.NumReadonlyUnsignedAccounts) > len(m.Accounts)-int(m.Header.NumRequireSignatures) {
+		return nil, errors.New("invalid message header")
+	}
+
+	accountKeys := make([]common.PublicKey, 0, len(m.Accounts)+numLoaded)
+	accountKeys = append(accountKeys, m.Accounts...)
+	accountKeys = append(accountKeys, loaded.Writable...)
+	accountKeys = append(accountKeys, loaded.Readonly...)
+
+	numSigners := int(m.Header.NumRequireSignatures)
+	numWritableSigners := numSigners - int(m.Header.NumReadonlySignedAccounts)
+	numWritableStatic := len(m.Accounts) - int(m.Header.NumReadonlyUnsignedAccounts)
+	numWritable := len(m.Accounts) + len(loaded.Writable)
+	isWritable := func(i int) bool {
+		switch {
+		case i < numSigners:
+			return i < numWritableSigners
+		case i < len(m.Accounts):
+			return i < numWritableStatic
+		default:
+			return i < numWritable
+		}
+	}
+
+	instructions := make([]Instruction, 0, len(m.Instructions))
+	for n, cins := range m.Instructions {
+		// program id can't be loaded from lookup tables
+		if cins.ProgramIDIndex < 0 || cins.ProgramIDIndex >= len(m.Accounts) {
+			return nil, fmt.Errorf("instruction %v: program id index %v is out of range", n, cins.ProgramIDIndex)
+		}
+		accounts := make([]AccountMeta, 0, len(cins.Accounts))
+		for _, idx := range cins.Accounts {
+			if idx < 0 || idx >= len(accountKeys) {
+				return nil, fmt.Errorf("instruction %v: account index %v is out of range", n, idx)
+			}
+			accounts = append(accounts, AccountMeta{
+				PubKey:     accountKeys[idx],
+				IsSigner:   idx < numSigners,
+				IsWritable: isWritable(idx),
+			})
+		}
+		instructions = append(instructions, Instruction{
+			ProgramID: m.Accounts[cins.ProgramIDIndex],
+			Accounts:  accounts,
+			Data:      cins.Data,
+		})
+	}
+	return instructions, nil
+}
+
 func (m Message) decompileLegacyMessageInstructions() []Instruction {
 	instructions := make([]Instruction, 0, len(m.Instructions))
 	for _, cins := range m.Instructions {
@@ -139,6 +275,8 @@ func (m Message) decompileLegacyMessageInstructions() []Instruction {
 	return instructions
 }
 
+// MessageDeserialize never panics on malformed data, counts are checked against the
+// remaining data before anything is allocated
 func MessageDeserialize(messageData []byte) (Message, error) {
 	if len(messageData) == 0 {
 		return Message{}, errors.New("empty message data")
@@ -147,28 +285,29 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 	var version MessageVersion
 	if v := uint8(messageData[0]); v > 127 {
 		version = MessageVersion(fmt.Sprintf("v%v", v-128))
+		if version != MessageVersionV0 {
+			return Message{}, fmt.Errorf("unsupported message version: %v", version)
+		}
 		messageData = messageData[1:]
 	} else {
 		version = MessageVersionLegacy
 	}
 
 	var numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts uint8
-	var t uint64
 	var err error
 	list := []*uint8{&numRequireSignatures, &numReadonlySignedAccounts, &numReadonlyUnsignedAccounts}
 	for i := 0; i < len(list); i++ {
-		t, err = parseUvarint(&messageData)
-		if err != nil || t > 255 {
+		*list[i], err = parseU8(&messageData)
+		if err != nil {
 			return Message{}, fmt.Errorf("message header #%d parse error: %v", i+1, err)
 		}
-		*list[i] = uint8(t)
 	}
 
 	accountCount, err := parseUvarint(&messageData)
 	if err != nil {
 		return Message{}, fmt.Errorf("falied to parse count of account, err: %v", err)
 	}
-	if len(messageData) < int(accountCount)*32 {
+	if accountCount > uint64(len(messageData)/32) {
 		return Message{}, errors.New("parse account error")
 	}
 	accounts := make([]common.PublicKey, 0, accountCount)
@@ -187,10 +326,14 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 	if err != nil {
 		return Message{}, fmt.Errorf("parse instruction count error: %v", err)
 	}
+	// an instruction takes at least 3 bytes
+	if instructionCount > uint64(len(messageData)/3) {
+		return Message{}, fmt.Errorf("parse instruction count error: %v instructions exceed the remaining %v bytes", instructionCount, len(messageData))
+	}
 
 	instructions := make([]CompiledInstruction, 0, instructionCount)
 	for i := 0; i < int(instructionCount); i++ {
-		programID, err := parseUvarint(&messageData)
+		programID, err := parseU8(&messageData)
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction #%d programID error: %v", i+1, err)
 		}
@@ -198,20 +341,22 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction #%d account count error: %v", i+1, err)
 		}
+		accountIdxList, err := parseBytes(&messageData, accountCount)
+		if err != nil {
+			return Message{}, fmt.Errorf("parse instruction #%d account idx error: %v", i+1, err)
+		}
 		accounts := make([]int, 0, accountCount)
-		for j := 0; j < int(accountCount); j++ {
-			accountIdx, err := parseUvarint(&messageData)
-			if err != nil {
-				return Message{}, fmt.Errorf("parse instruction #%d account #%d idx error: %v", i+1, j+1, err)
-			}
+		for _, accountIdx := range accountIdxList {
 			accounts = append(accounts, int(accountIdx))
 		}
 		dataLen, err := parseUvarint(&messageData)
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction #%d data length error: %v", i+1, err)
 		}
-		var data []byte
-		data, messageData = messageData[:dataLen], messageData[dataLen:]
+		data, err := parseBytes(&messageData, dataLen)
+		if err != nil {
+			return Message{}, fmt.Errorf("parse instruction #%d data error: %v", i+1, err)
+		}
 
 		instructions = append(instructions, CompiledInstruction{
 			ProgramIDIndex: int(programID),
@@ -226,29 +371,39 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction count error: %v", err)
 		}
+		// a lookup table takes at least 34 bytes
+		if addressLookupTableCount > uint64(len(messageData)/34) {
+			return Message{}, fmt.Errorf("failed to parse address lookup tables, %v tables exceed the remaining %v bytes", addressLookupTableCount, len(messageData))
+		}
 
 		for i := uint64(0); i < addressLookupTableCount; i++ {
-			addressLookupTablePubkey := common.PublicKeyFromBytes(messageData[:32])
-			messageData = messageData[32:]
+			addressLookupTablePubkey, err := parseBytes(&messageData, 32)
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to parse address lookup table pubkey, err: %v", err)
+			}
 
 			writableAccountIdxCount, err := parseUvarint(&messageData)
 			if err != nil {
 				return Message{}, fmt.Errorf("failed to parse address lookup table writable account idx count, err: %v", err)
 			}
-			var writableAccountIdxList []uint8
-			writableAccountIdxList, messageData = messageData[:writableAccountIdxCount], messageData[writableAccountIdxCount:]
+			writableAccountIdxList, err := parseBytes(&messageData, writableAccountIdxCount)
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to parse address lookup table writable account idx, err: %v", err)
+			}
 
 			readOnlyAccountIdxCount, err := parseUvarint(&messageData)
 			if err != nil {
 				return Message{}, fmt.Errorf("failed to parse address lookup table readOnly account idx count, err: %v", err)
 			}
-			var readOnlyAccountIdxList []uint8
-			readOnlyAccountIdxList, messageData = messageData[:readOnlyAccountIdxCount], messageData[readOnlyAccountIdxCount:]
+			readOnlyAccountIdxList, err := parseBytes(&messageData, readOnlyAccountIdxCount)
+			if err != nil {
+				return Message{}, fmt.Errorf("failed to parse address lookup table readOnly account idx, err: %v", err)
+			}
 
 			compiledAddressLookupTables = append(
 				compiledAddressLookupTables,
 				CompiledAddressLookupTable{
-					AccountKey:      addressLookupTablePubkey,
+					AccountKey:      common.PublicKeyFromBytes(addressLookupTablePubkey),
 					WritableIndexes: writableAccountIdxList,
 					ReadonlyIndexes: readOnlyAccountIdxList,
 				},
@@ -289,6 +444,9 @@ type NewMessageParam struct {
 	RecentBlockhash string
 	// v0 transaction
 	AddressLookupTableAccounts []AddressLookupTableAccount
+	// Version is empty by default, the message is v0 if it uses lookup tables and
+	// legacy otherwise. set it to keep a v0 message without lookup tables v0.
+	Version MessageVersion
 }
 
 type CompiledKeys struct {
@@ -351,6 +509,10 @@ func NewMessage(param NewMessageParam) Message {
 	for _, addressLookupTableAccount := range param.AddressLookupTableAccounts {
 		m := map[common.PublicKey]uint8{}
 		for i, address := range addressLookupTableAccount.Addresses {
+			// an index is a u8, the rest can't be referenced
+			if i > math.MaxUint8 {
+				break
+			}
 			m[address] = uint8(i)
 		}
 		addressLookupTableMaps = append(addressLookupTableMaps, m)
@@ -440,9 +602,12 @@ NEXT_ACCOUNT:
 		}
 	}
 
-	var version MessageVersion = MessageVersionLegacy
-	if addressLookupTableAccountCount > 0 {
-		version = MessageVersionV0
+	version := param.Version
+	if version == "" {
+		version = MessageVersionLegacy
+		if addressLookupTableAccountCount > 0 {
+			version = MessageVersionV0
+		}
 	}
 
 	publicKeyToIdx := map[common.PublicKey]int{}
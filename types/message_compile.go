@@ -0,0 +1,158 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/pkg/bincode"
+)
+
+const (
+	// PacketDataSize is the max size of a serialized transaction
+	PacketDataSize = 1232
+	// MaxAccountKeys is the max number of static and loaded accounts, an account index is a u8
+	MaxAccountKeys = 256
+	// MaxSigners is the max number of signers the message header can hold
+	MaxSigners = math.MaxUint8
+	// MaxAddressLookupTableAddresses is the max number of addresses a lookup table can hold
+	MaxAddressLookupTableAddresses = 256
+)
+
+var (
+	ErrTooManyAccountKeys                  = errors.New("too many account keys")
+	ErrTooManySigners                      = errors.New("too many signers")
+	ErrTransactionTooLarge                 = errors.New("transaction too large")
+	ErrProgramIdFromAddressLookupTable     = errors.New("program id can't be loaded from address lookup table")
+	ErrDuplicateAddressLookupTable         = errors.New("duplicate address lookup table")
+	ErrAddressLookupTableTooManyAddresses  = errors.New("address lookup table has too many addresses")
+	ErrInvalidMessageHeader                = errors.New("invalid message header")
+	ErrCompiledInstructionIndexOutOfRange  = errors.New("compiled instruction index out of range")
+	ErrAddressLookupTableWithLegacyMessage = errors.New("legacy message can't use address lookup tables")
+)
+
+// TransactionSizeError means the serialized transaction exceeds PacketDataSize
+type TransactionSizeError struct {
+	Size int
+}
+
+func (e *TransactionSizeError) Error() string {
+	return fmt.Sprintf("%v, size: %v, exceeded the %v-byte limit by %v bytes", ErrTransactionTooLarge, e.Size, PacketDataSize, e.Exceeded())
+}
+
+// Exceeded returns how many bytes need to be removed
+func (e *TransactionSizeError) Exceeded() int {
+	return e.Size - PacketDataSize
+}
+
+func (e *TransactionSizeError) Is(target error) bool {
+	return target == ErrTransactionTooLarge
+}
+
+// CompileMessage is NewMessage with validation. it returns an error instead of a
+// message which can't be sent, e.g. too many accounts or too large to fit in a packet.
+func CompileMessage(param NewMessageParam) (Message, error) {
+	seen := make(map[common.PublicKey]struct{}, len(param.AddressLookupTableAccounts))
+	for _, table := range param.AddressLookupTableAccounts {
+		if _, ok := seen[table.Key]; ok {
+			return Message{}, fmt.Errorf("%w, %v", ErrDuplicateAddressLookupTable, table.Key)
+		}
+		seen[table.Key] = struct{}{}
+		if len(table.Addresses) > MaxAddressLookupTableAddresses {
+			return Message{}, fmt.Errorf("%w, %v has %v addresses", ErrAddressLookupTableTooManyAddresses, table.Key, len(table.Addresses))
+		}
+	}
+
+	compiledKeys := NewCompiledKeys(param.Instructions, &param.FeePayer)
+	numSigners := 0
+	for key, meta := range compiledKeys.KeyMetaMap {
+		// the fee payer is always a signer even if it's zero
+		if meta.IsSigner && key != param.FeePayer {
+			numSigners++
+		}
+	}
+	numSigners++
+	if numSigners > MaxSigners {
+		return Message{}, fmt.Errorf("%w, max: %v, got: %v", ErrTooManySigners, MaxSigners, numSigners)
+	}
+	numKeys := len(compiledKeys.KeyMetaMap)
+	if _, ok := compiledKeys.KeyMetaMap[param.FeePayer]; !ok {
+		numKeys++
+	}
+	if numKeys > MaxAccountKeys {
+		return Message{}, fmt.Errorf("%w, max: %v, got: %v", ErrTooManyAccountKeys, MaxAccountKeys, numKeys)
+	}
+
+	message := NewMessage(param)
+	if err := message.Validate(); err != nil {
+		return Message{}, err
+	}
+	return message, nil
+}
+
+// Validate checks the message can be sent. lookup tables aren't resolved so loaded
+// addresses are only counted.
+func (m *Message) Validate() error {
+	if err := m.checkVersion(); err != nil {
+		return err
+	}
+	numLoaded := 0
+	if m.Version == MessageVersionV0 {
+		seen := make(map[common.PublicKey]struct{}, len(m.AddressLookupTables))
+		for _, lookup := range m.AddressLookupTables {
+			if _, ok := seen[lookup.AccountKey]; ok {
+				return fmt.Errorf("%w, %v", ErrDuplicateAddressLookupTable, lookup.AccountKey)
+			}
+			seen[lookup.AccountKey] = struct{}{}
+			numLoaded += len(lookup.WritableIndexes) + len(lookup.ReadonlyIndexes)
+		}
+	} else if len(m.AddressLookupTables) > 0 {
+		return ErrAddressLookupTableWithLegacyMessage
+	}
+
+	numKeys := len(m.Accounts) + numLoaded
+	if numKeys > MaxAccountKeys {
+		return fmt.Errorf("%w, max: %v, got: %v", ErrTooManyAccountKeys, MaxAccountKeys, numKeys)
+	}
+	if m.Header.NumRequireSignatures == 0 ||
+		int(m.Header.NumRequireSignatures) > len(m.Accounts) ||
+		m.Header.NumReadonlySignedAccounts >= m.Header.NumRequireSignatures ||
+		int(m.Header.NumReadonlyUnsignedAccounts) > len(m.Accounts)-int(m.Header.NumRequireSignatures) {
+		return ErrInvalidMessageHeader
+	}
+
+	for n, instruction := range m.Instructions {
+		if instruction.ProgramIDIndex >= len(m.Accounts) && instruction.ProgramIDIndex < numKeys {
+			return fmt.Errorf("%w, instruction: %v", ErrProgramIdFromAddressLookupTable, n)
+		}
+		// the fee payer can't be a program
+		if instruction.ProgramIDIndex <= 0 || instruction.ProgramIDIndex >= numKeys {
+			return fmt.Errorf("%w, instruction: %v, program id index: %v", ErrCompiledInstructionIndexOutOfRange, n, instruction.ProgramIDIndex)
+		}
+		for _, idx := range instruction.Accounts {
+			if idx < 0 || idx >= numKeys {
+				return fmt.Errorf("%w, instruction: %v, account index: %v", ErrCompiledInstructionIndexOutOfRange, n, idx)
+			}
+		}
+	}
+
+	size, err := m.TransactionSize()
+	if err != nil {
+		return err
+	}
+	if size > PacketDataSize {
+		return &TransactionSizeError{Size: size}
+	}
+	return nil
+}
+
+// TransactionSize returns the exact size of the signed transaction of the message
+func (m *Message) TransactionSize() (int, error) {
+	data, err := m.Serialize()
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	numSigners := uint64(m.Header.NumRequireSignatures)
+	return len(bincode.UintToVarLenBytes(numSigners)) + int(numSigners)*64 + len(data), nil
+}
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPublicKey(n uint32) common.PublicKey {
+	var pubkey common.PublicKey
+	pubkey[0] = 1
+	binary.LittleEndian.PutUint32(pubkey[1:], n)
+	return pubkey
+}
+
+func TestCompileMessage(t *testing.T) {
+	feePayer := NewAccount()
+	programID := common.PublicKeyFromString("CustomProgram111111111111111111111111111111")
+	blockhash := "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5"
+	instructionWithAccounts := func(n int, isSigner bool, data []byte) Instruction {
+		accounts := make([]AccountMeta, 0, n)
+		for i := 0; i < n; i++ {
+			accounts = append(accounts, AccountMeta{PubKey: testPublicKey(uint32(i)), IsSigner: isSigner, IsWritable: true})
+		}
+		return Instruction{ProgramID: programID, Accounts: accounts, Data: data}
+	}
+
+	t.Run("size is exact", func(t *testing.T) {
+		signer := NewAccount()
+		message, err := CompileMessage(NewMessageParam{
+			FeePayer: feePayer.PublicKey,
+			Instructions: []Instruction{
+				{
+					ProgramID: programID,
+					Accounts:  []AccountMeta{{PubKey: signer.PublicKey, IsSigner: true, IsWritable: false}},
+					Data:      []byte{1, 2, 3},
+				},
+			},
+			RecentBlockhash: blockhash,
+		})
+		require.NoError(t, err)
+		size, err := message.TransactionSize()
+		require.NoError(t, err)
+
+		tx, err := NewTransaction(NewTransactionParam{Message: message, Signers: []Account{feePayer, signer}})
+		require.NoError(t, err)
+		raw, err := tx.Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, len(raw), size)
+	})
+
+	t.Run("packet size", func(t *testing.T) {
+		param := NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			RecentBlockhash: blockhash,
+		}
+		// 1 + 64 + (3 + 1 + 32*2 + 32 + 1 + (1 + 1 + 2 + 1000)) = 1170
+		param.Instructions = []Instruction{{ProgramID: programID, Data: make([]byte, 1000)}}
+		message, err := CompileMessage(param)
+		require.NoError(t, err)
+		size, err := message.TransactionSize()
+		require.NoError(t, err)
+		assert.Equal(t, 1170, size)
+
+		param.Instructions = []Instruction{{ProgramID: programID, Data: make([]byte, 1100)}}
+		_, err = CompileMessage(param)
+		assert.ErrorIs(t, err, ErrTransactionTooLarge)
+		var sizeErr *TransactionSizeError
+		require.True(t, errors.As(err, &sizeErr))
+		assert.Equal(t, 1270, sizeErr.Size)
+		assert.Equal(t, 38, sizeErr.Exceeded())
+		assert.EqualError(t, err, "transaction too large, size: 1270, exceeded the 1232-byte limit by 38 bytes")
+	})
+
+	t.Run("too many account keys", func(t *testing.T) {
+		addresses := make([]common.PublicKey, 0, 256)
+		for i := 0; i < 256; i++ {
+			addresses = append(addresses, testPublicKey(uint32(i)))
+		}
+		_, err := CompileMessage(NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			Instructions:    []Instruction{instructionWithAccounts(256, false, nil)},
+			RecentBlockhash: blockhash,
+			AddressLookupTableAccounts: []AddressLookupTableAccount{
+				{Key: testPublicKey(1000), Addresses: addresses},
+			},
+		})
+		assert.ErrorIs(t, err, ErrTooManyAccountKeys)
+		assert.EqualError(t, err, "too many account keys, max: 256, got: 258")
+	})
+
+	t.Run("too many signers", func(t *testing.T) {
+		_, err := CompileMessage(NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			Instructions:    []Instruction{instructionWithAccounts(255, true, nil)},
+			RecentBlockhash: blockhash,
+		})
+		assert.ErrorIs(t, err, ErrTooManySigners)
+	})
+
+	t.Run("duplicate lookup table", func(t *testing.T) {
+		_, err := CompileMessage(NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			Instructions:    []Instruction{instructionWithAccounts(2, false, nil)},
+			RecentBlockhash: blockhash,
+			AddressLookupTableAccounts: []AddressLookupTableAccount{
+				{Key: testPublicKey(1000), Addresses: []common.PublicKey{testPublicKey(0)}},
+				{Key: testPublicKey(1000), Addresses: []common.PublicKey{testPublicKey(1)}},
+			},
+		})
+		assert.ErrorIs(t, err, ErrDuplicateAddressLookupTable)
+	})
+
+	t.Run("lookup table index overflow", func(t *testing.T) {
+		_, err := CompileMessage(NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			Instructions:    []Instruction{instructionWithAccounts(1, false, nil)},
+			RecentBlockhash: blockhash,
+			AddressLookupTableAccounts: []AddressLookupTableAccount{
+				{Key: testPublicKey(1000), Addresses: make([]common.PublicKey, 257)},
+			},
+		})
+		assert.ErrorIs(t, err, ErrAddressLookupTableTooManyAddresses)
+	})
+
+	t.Run("program id never comes from lookup tables", func(t *testing.T) {
+		message, err := CompileMessage(NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			Instructions:    []Instruction{instructionWithAccounts(1, false, nil)},
+			RecentBlockhash: blockhash,
+			AddressLookupTableAccounts: []AddressLookupTableAccount{
+				{Key: testPublicKey(1000), Addresses: []common.PublicKey{programID, testPublicKey(0)}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []common.PublicKey{feePayer.PublicKey, programID}, message.Accounts)
+		assert.Equal(t, []uint8{1}, message.AddressLookupTables[0].WritableIndexes)
+	})
+}
+
+func TestMessage_Validate(t *testing.T) {
+	message := Message{
+		Version: MessageVersionV0,
+		Header: MessageHeader{
+			NumRequireSignatures:        1,
+			NumReadonlySignedAccounts:   0,
+			NumReadonlyUnsignedAccounts: 0,
+		},
+		Accounts:        []common.PublicKey{testPublicKey(0), testPublicKey(1)},
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		Instructions: []CompiledInstruction{
+			{ProgramIDIndex: 1, Accounts: []int{0, 2}, Data: []byte{}},
+		},
+		AddressLookupTables: []CompiledAddressLookupTable{
+			{AccountKey: testPublicKey(1000), WritableIndexes: []uint8{0}, ReadonlyIndexes: []uint8{}},
+		},
+	}
+	assert.NoError(t, message.Validate())
+
+	fromLookupTable := message
+	fromLookupTable.Instructions = []CompiledInstruction{{ProgramIDIndex: 2, Accounts: []int{}, Data: []byte{}}}
+	assert.ErrorIs(t, fromLookupTable.Validate(), ErrProgramIdFromAddressLookupTable)
+
+	outOfRange := message
+	outOfRange.Instructions = []CompiledInstruction{{ProgramIDIndex: 1, Accounts: []int{3}, Data: []byte{}}}
+	assert.ErrorIs(t, outOfRange.Validate(), ErrCompiledInstructionIndexOutOfRange)
+
+	duplicate := message
+	duplicate.AddressLookupTables = append(duplicate.AddressLookupTables, duplicate.AddressLookupTables[0])
+	assert.ErrorIs(t, duplicate.Validate(), ErrDuplicateAddressLookupTable)
+
+	invalidHeader := message
+	invalidHeader.Header.NumRequireSignatures = 0
+	assert.ErrorIs(t, invalidHeader.Validate(), ErrInvalidMessageHeader)
+}
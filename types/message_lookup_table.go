@@ -0,0 +1,137 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/blocto/solana-go-sdk/common"
+)
+
+// AddressLookupTableSelection reports the lookup tables a message uses
+type AddressLookupTableSelection struct {
+	// Accounts are the chosen tables in the order of the message, empty for a legacy message
+	Accounts []AddressLookupTableAccount
+	// Lookups are the indexes loaded from each chosen table
+	Lookups []CompiledAddressLookupTable
+	// Size is the transaction size of the message
+	Size int
+}
+
+// CompileMessageWithAddressLookupTableSelection compiles a validated message with the
+// subset of param.AddressLookupTableAccounts which minimizes the size. invoked program
+// ids and signers are never loaded from tables. it falls back to a legacy message if
+// no table makes it smaller. the subset is chosen greedily by bytes saved.
+func CompileMessageWithAddressLookupTableSelection(param NewMessageParam) (Message, AddressLookupTableSelection, error) {
+	legacyParam := param
+	legacyParam.AddressLookupTableAccounts = nil
+
+	selected := selectAddressLookupTables(param)
+	if len(selected) == 0 {
+		return compileWithSelection(legacyParam)
+	}
+
+	// drop tables which don't pay for themselves once others are chosen
+	v0Param := param
+	v0Param.AddressLookupTableAccounts = selected
+	v0Size, err := messageTransactionSize(v0Param)
+	if err != nil {
+		return Message{}, AddressLookupTableSelection{}, err
+	}
+	for i := len(selected) - 1; i >= 0 && len(selected) > 1; i-- {
+		tables := make([]AddressLookupTableAccount, 0, len(selected)-1)
+		tables = append(tables, selected[:i]...)
+		tables = append(tables, selected[i+1:]...)
+		v0Param.AddressLookupTableAccounts = tables
+		size, err := messageTransactionSize(v0Param)
+		if err != nil {
+			return Message{}, AddressLookupTableSelection{}, err
+		}
+		if size < v0Size {
+			selected, v0Size = tables, size
+		}
+	}
+	v0Param.AddressLookupTableAccounts = selected
+
+	legacySize, err := messageTransactionSize(legacyParam)
+	if err != nil {
+		return Message{}, AddressLookupTableSelection{}, err
+	}
+	if legacySize <= v0Size {
+		return compileWithSelection(legacyParam)
+	}
+	return compileWithSelection(v0Param)
+}
+
+// selectAddressLookupTables picks the table which saves the most bytes until no table helps.
+// a loaded address costs 1 byte instead of 32 and a table costs 34 bytes.
+func selectAddressLookupTables(param NewMessageParam) []AddressLookupTableAccount {
+	compiledKeys := NewCompiledKeys(param.Instructions, &param.FeePayer)
+	loadable := map[common.PublicKey]bool{}
+	for key, meta := range compiledKeys.KeyMetaMap {
+		if key != param.FeePayer && !meta.IsSigner && !meta.IsInvoked {
+			loadable[key] = true
+		}
+	}
+
+	candidates := append([]AddressLookupTableAccount{}, param.AddressLookupTableAccounts...)
+	selected := []AddressLookupTableAccount{}
+	for len(loadable) > 0 && len(candidates) > 0 {
+		best, bestCount := -1, 0
+		for i, table := range candidates {
+			count := 0
+			seen := map[common.PublicKey]bool{}
+			for j, address := range table.Addresses {
+				if j >= MaxAddressLookupTableAddresses {
+					break
+				}
+				if loadable[address] && !seen[address] {
+					seen[address] = true
+					count++
+				}
+			}
+			if count > bestCount {
+				best, bestCount = i, count
+			}
+		}
+		if best < 0 || 31*bestCount <= 34 {
+			break
+		}
+		table := candidates[best]
+		for j, address := range table.Addresses {
+			if j >= MaxAddressLookupTableAddresses {
+				break
+			}
+			delete(loadable, address)
+		}
+		selected = append(selected, table)
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+	return selected
+}
+
+func messageTransactionSize(param NewMessageParam) (int, error) {
+	message := NewMessage(param)
+	return message.TransactionSize()
+}
+
+func compileWithSelection(param NewMessageParam) (Message, AddressLookupTableSelection, error) {
+	message, err := CompileMessage(param)
+	if err != nil {
+		return Message{}, AddressLookupTableSelection{}, err
+	}
+	size, err := message.TransactionSize()
+	if err != nil {
+		return Message{}, AddressLookupTableSelection{}, fmt.Errorf("failed to get transaction size, err: %v", err)
+	}
+
+	selection := AddressLookupTableSelection{Size: size}
+	for _, lookup := range message.AddressLookupTables {
+		for _, table := range param.AddressLookupTableAccounts {
+			if table.Key == lookup.AccountKey {
+				selection.Accounts = append(selection.Accounts, table)
+				break
+			}
+		}
+		selection.Lookups = append(selection.Lookups, lookup)
+	}
+	return message, selection, nil
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileMessageWithAddressLookupTableSelection(t *testing.T) {
+	feePayer := testPublicKey(10000)
+	signer := testPublicKey(10001)
+	programID := common.PublicKeyFromString("CustomProgram111111111111111111111111111111")
+	blockhash := "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5"
+	instruction := Instruction{
+		ProgramID: programID,
+		Accounts: []AccountMeta{
+			{PubKey: signer, IsSigner: true, IsWritable: false},
+			{PubKey: testPublicKey(0), IsSigner: false, IsWritable: true},
+			{PubKey: testPublicKey(1), IsSigner: false, IsWritable: true},
+			{PubKey: testPublicKey(2), IsSigner: false, IsWritable: false},
+			{PubKey: testPublicKey(3), IsSigner: false, IsWritable: false},
+			{PubKey: testPublicKey(4), IsSigner: false, IsWritable: false},
+		},
+		Data: []byte{1},
+	}
+
+	// small covers 2 accounts, large covers 4 and the program and the signer which can't be loaded
+	small := AddressLookupTableAccount{Key: testPublicKey(20000), Addresses: []common.PublicKey{testPublicKey(0), testPublicKey(1)}}
+	large := AddressLookupTableAccount{Key: testPublicKey(20001), Addresses: []common.PublicKey{programID, signer, testPublicKey(1), testPublicKey(2), testPublicKey(3), testPublicKey(4)}}
+	// rest covers the account large doesn't
+	rest := AddressLookupTableAccount{Key: testPublicKey(20002), Addresses: []common.PublicKey{testPublicKey(0), testPublicKey(9)}}
+	unrelated := AddressLookupTableAccount{Key: testPublicKey(20003), Addresses: []common.PublicKey{testPublicKey(7), testPublicKey(8)}}
+
+	t.Run("choose tables", func(t *testing.T) {
+		param := NewMessageParam{
+			FeePayer:                   feePayer,
+			Instructions:               []Instruction{instruction},
+			RecentBlockhash:            blockhash,
+			AddressLookupTableAccounts: []AddressLookupTableAccount{unrelated, small, rest, large},
+		}
+		message, selection, err := CompileMessageWithAddressLookupTableSelection(param)
+		require.NoError(t, err)
+		assert.Equal(t, MessageVersion(MessageVersionV0), message.Version)
+		assert.Equal(t, []AddressLookupTableAccount{large}, selection.Accounts)
+		assert.Equal(t, []CompiledAddressLookupTable{
+			{AccountKey: large.Key, WritableIndexes: []uint8{2}, ReadonlyIndexes: []uint8{3, 4, 5}},
+		}, selection.Lookups)
+		assert.Equal(t, []common.PublicKey{feePayer, signer, testPublicKey(0), programID}, message.Accounts)
+
+		size, err := message.TransactionSize()
+		require.NoError(t, err)
+		assert.Equal(t, size, selection.Size)
+
+		// it is the smallest of every subset
+		candidates := param.AddressLookupTableAccounts
+		for mask := 0; mask < 1<<len(candidates); mask++ {
+			var tables []AddressLookupTableAccount
+			for i := range candidates {
+				if mask&(1<<i) != 0 {
+					tables = append(tables, candidates[i])
+				}
+			}
+			p := param
+			p.AddressLookupTableAccounts = tables
+			m := NewMessage(p)
+			s, err := m.TransactionSize()
+			require.NoError(t, err)
+			assert.LessOrEqual(t, selection.Size, s, "tables %v", mask)
+		}
+
+		instructions, err := message.DecompileInstructionsWithAddressLookupTables(selection.Accounts)
+		require.NoError(t, err)
+		assert.Equal(t, []Instruction{instruction}, instructions)
+	})
+
+	t.Run("legacy if no table helps", func(t *testing.T) {
+		message, selection, err := CompileMessageWithAddressLookupTableSelection(NewMessageParam{
+			FeePayer:                   feePayer,
+			Instructions:               []Instruction{instruction},
+			RecentBlockhash:            blockhash,
+			AddressLookupTableAccounts: []AddressLookupTableAccount{unrelated, {Key: testPublicKey(20004), Addresses: []common.PublicKey{programID, signer, testPublicKey(0)}}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, MessageVersion(MessageVersionLegacy), message.Version)
+		assert.Empty(t, selection.Accounts)
+		assert.Empty(t, selection.Lookups)
+		assert.Equal(t, NewMessage(NewMessageParam{
+			FeePayer:        feePayer,
+			Instructions:    []Instruction{instruction},
+			RecentBlockhash: blockhash,
+		}), message)
+	})
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blocto/solana-go-sdk/common"
+)
+
+// MaxTransactionAccountLocks is the max number of accounts a transaction can lock
+const MaxTransactionAccountLocks = 64
+
+var ErrTooManyAccountLocks = errors.New("too many account locks")
+
+// InstructionGroup is instructions which must be in the same message
+type InstructionGroup []Instruction
+
+// NewInstructionGroups puts every instruction in its own group
+func NewInstructionGroups(instructions ...Instruction) []InstructionGroup {
+	groups := make([]InstructionGroup, 0, len(instructions))
+	for _, instruction := range instructions {
+		groups = append(groups, InstructionGroup{instruction})
+	}
+	return groups
+}
+
+type PackMessagesParam struct {
+	FeePayer                   common.PublicKey
+	RecentBlockhash            string
+	AddressLookupTableAccounts []AddressLookupTableAccount
+	// PrefixInstructions are put at the beginning of every message, e.g. compute budget or memo
+	PrefixInstructions []Instruction
+	// Instructions are packed in order, a group is never split
+	Instructions []InstructionGroup
+	// MaxAccountLocks limits accounts of a message, default: MaxTransactionAccountLocks
+	MaxAccountLocks int
+}
+
+// PackMessages packs instructions into the minimum number of messages. each message
+// keeps the order, fits in a packet and stays under the account limits.
+func PackMessages(param PackMessagesParam) ([]Message, error) {
+	if param.MaxAccountLocks <= 0 {
+		param.MaxAccountLocks = MaxTransactionAccountLocks
+	}
+
+	messages := []Message{}
+	var current []Instruction
+	var currentMessage Message
+	for i, group := range param.Instructions {
+		if len(group) == 0 {
+			continue
+		}
+		candidate := make([]Instruction, 0, len(current)+len(group))
+		candidate = append(candidate, current...)
+		candidate = append(candidate, group...)
+		message, err := param.compile(candidate)
+		if err == nil {
+			current, currentMessage = candidate, message
+			continue
+		}
+		if !isPackLimitError(err) || len(current) == 0 {
+			return nil, fmt.Errorf("instruction group %v doesn't fit in a message, err: %w", i, err)
+		}
+
+		// packing the sequence greedily is optimal since removing instructions never
+		// makes a message larger
+		messages = append(messages, currentMessage)
+		current = append([]Instruction{}, group...)
+		currentMessage, err = param.compile(current)
+		if err != nil {
+			return nil, fmt.Errorf("instruction group %v doesn't fit in a message, err: %w", i, err)
+		}
+	}
+	if len(current) > 0 {
+		messages = append(messages, currentMessage)
+	}
+	return messages, nil
+}
+
+func (p PackMessagesParam) compile(instructions []Instruction) (Message, error) {
+	all := make([]Instruction, 0, len(p.PrefixInstructions)+len(instructions))
+	all = append(all, p.PrefixInstructions...)
+	all = append(all, instructions...)
+	message, err := CompileMessage(NewMessageParam{
+		FeePayer:                   p.FeePayer,
+		Instructions:               all,
+		RecentBlockhash:            p.RecentBlockhash,
+		AddressLookupTableAccounts: p.AddressLookupTableAccounts,
+	})
+	if err != nil {
+		return Message{}, err
+	}
+	numAccounts := len(message.Accounts)
+	for _, lookup := range message.AddressLookupTables {
+		numAccounts += len(lookup.WritableIndexes) + len(lookup.ReadonlyIndexes)
+	}
+	if numAccounts > p.MaxAccountLocks {
+		return Message{}, fmt.Errorf("%w, max: %v, got: %v", ErrTooManyAccountLocks, p.MaxAccountLocks, numAccounts)
+	}
+	return message, nil
+}
+
+func isPackLimitError(err error) bool {
+	return errors.Is(err, ErrTransactionTooLarge) ||
+		errors.Is(err, ErrTooManyAccountKeys) ||
+		errors.Is(err, ErrTooManySigners) ||
+		errors.Is(err, ErrTooManyAccountLocks)
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackMessages(t *testing.T) {
+	feePayer := testPublicKey(10000)
+	systemProgramID := common.PublicKeyFromString("11111111111111111111111111111111")
+	memoProgramID := common.PublicKeyFromString("MemoSq4gqABAXKb96qnH8TuNZBSfgdwCPL8FoqZuaqv")
+	transfer := func(to common.PublicKey) Instruction {
+		data := make([]byte, 12)
+		binary.LittleEndian.PutUint32(data, 2)
+		binary.LittleEndian.PutUint64(data[4:], 1)
+		return Instruction{
+			ProgramID: systemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer, IsSigner: true, IsWritable: true},
+				{PubKey: to, IsSigner: false, IsWritable: true},
+			},
+			Data: data,
+		}
+	}
+	memo := Instruction{ProgramID: memoProgramID, Accounts: []AccountMeta{}, Data: []byte("payout")}
+
+	instructions := make([]Instruction, 0, 300)
+	for i := 0; i < 300; i++ {
+		instructions = append(instructions, transfer(testPublicKey(uint32(i))))
+	}
+	param := PackMessagesParam{
+		FeePayer:           feePayer,
+		RecentBlockhash:    "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		PrefixInstructions: []Instruction{memo},
+		Instructions:       NewInstructionGroups(instructions...),
+	}
+
+	// packing keeps the order and every message is full
+	checkPacked := func(t *testing.T, param PackMessagesParam, messages []Message) {
+		var packed []Instruction
+		for i, message := range messages {
+			require.NoError(t, message.Validate())
+			got := message.DecompileInstructions()
+			assert.Equal(t, memo, got[0])
+			packed = append(packed, got[1:]...)
+
+			if i+1 < len(messages) {
+				next := messages[i+1].DecompileInstructions()[1]
+				_, err := param.compile(append(got[1:], next))
+				assert.True(t, isPackLimitError(err), "message %v isn't full", i)
+			}
+		}
+		var expected []Instruction
+		for _, group := range param.Instructions {
+			expected = append(expected, group...)
+		}
+		assert.Equal(t, expected, packed)
+	}
+
+	t.Run("size and account locks", func(t *testing.T) {
+		messages, err := PackMessages(param)
+		require.NoError(t, err)
+		// a message fits 20 transfers and the memo by size
+		assert.Equal(t, 15, len(messages))
+		checkPacked(t, param, messages)
+
+		param := param
+		param.MaxAccountLocks = 10
+		messages, err = PackMessages(param)
+		require.NoError(t, err)
+		// fee payer, system program and memo program leave 7 recipients
+		assert.Equal(t, 43, len(messages))
+		checkPacked(t, param, messages)
+	})
+
+	t.Run("lookup tables", func(t *testing.T) {
+		addresses := make([]common.PublicKey, 0, 256)
+		for i := 0; i < 256; i++ {
+			addresses = append(addresses, testPublicKey(uint32(i)))
+		}
+		param := param
+		param.AddressLookupTableAccounts = []AddressLookupTableAccount{{Key: testPublicKey(20000), Addresses: addresses}}
+		param.MaxAccountLocks = MaxAccountKeys
+		messages, err := PackMessages(param)
+		require.NoError(t, err)
+		// lookup tables fit more transfers in a message, recipients out of the table take more
+		assert.Equal(t, 7, len(messages))
+		for _, message := range messages {
+			assert.Equal(t, MessageVersion(MessageVersionV0), message.Version)
+		}
+		for i, message := range messages {
+			require.NoError(t, message.Validate())
+			if i+1 < len(messages) {
+				instructions, err := message.DecompileInstructionsWithAddressLookupTables(param.AddressLookupTableAccounts)
+				require.NoError(t, err)
+				next, err := messages[i+1].DecompileInstructionsWithAddressLookupTables(param.AddressLookupTableAccounts)
+				require.NoError(t, err)
+				_, err = param.compile(append(instructions[1:], next[1]))
+				assert.True(t, isPackLimitError(err))
+			}
+		}
+	})
+
+	t.Run("atomic groups", func(t *testing.T) {
+		param := param
+		param.Instructions = nil
+		for i := 0; i < 30; i++ {
+			param.Instructions = append(param.Instructions, InstructionGroup{
+				transfer(testPublicKey(uint32(3 * i))),
+				transfer(testPublicKey(uint32(3*i + 1))),
+				transfer(testPublicKey(uint32(3*i + 2))),
+			})
+		}
+		messages, err := PackMessages(param)
+		require.NoError(t, err)
+		for _, message := range messages {
+			// 3 transfers per group and a memo
+			assert.Equal(t, 1, len(message.Instructions)%3)
+		}
+		assert.Equal(t, 5, len(messages))
+		checkPacked(t, param, messages)
+	})
+
+	t.Run("group too large", func(t *testing.T) {
+		param := param
+		param.Instructions = []InstructionGroup{
+			{transfer(testPublicKey(0))},
+			instructions[:100],
+		}
+		_, err := PackMessages(param)
+		assert.ErrorIs(t, err, ErrTransactionTooLarge)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		param := param
+		param.Instructions = nil
+		messages, err := PackMessages(param)
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+}
@@ -110,7 +110,16 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 					},
 				},
 			},
-			panic: "hasn't supported",
+			want: []Instruction{
+				{
+					ProgramID: common.SystemProgramID,
+					Accounts: []AccountMeta{
+						{PubKey: common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"), IsSigner: true, IsWritable: true},
+						{PubKey: common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i"), IsSigner: false, IsWritable: true},
+					},
+					Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+				},
+			},
 		},
 		{
 			fields: fields{
@@ -141,7 +150,16 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 					},
 				},
 			},
-			panic: "hasn't supported",
+			want: []Instruction{
+				{
+					ProgramID: common.SystemProgramID,
+					Accounts: []AccountMeta{
+						{PubKey: common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"), IsSigner: true, IsWritable: true},
+						{PubKey: common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i"), IsSigner: false, IsWritable: true},
+					},
+					Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+				},
+			},
 		},
 		{
 			fields: fields{
@@ -171,7 +189,7 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 					},
 				},
 			},
-			panic: "hasn't supported",
+			panic: "v0 message which loads addresses from lookup tables needs the loaded addresses to decompile",
 		},
 	}
 	for _, tt := range tests {
@@ -195,6 +213,91 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 	}
 }
 
+func TestMessage_DecompileInstructionsWithAddressLookupTables(t *testing.T) {
+	alt := AddressLookupTableAccount{
+		Key: common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+		Addresses: []common.PublicKey{
+			common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i"),
+			common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"),
+			common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		},
+	}
+	m := Message{
+		Version: MessageVersionV0,
+		Header: MessageHeader{
+			NumRequireSignatures:        2,
+			NumReadonlySignedAccounts:   1,
+			NumReadonlyUnsignedAccounts: 1,
+		},
+		Accounts: []common.PublicKey{
+			common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"),
+			common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz"),
+			common.PublicKeyFromString("DdxNp4kbPkYjK7hR3ESrmGXeACDsSYx4V4qWKAn5Tu6x"),
+			common.SystemProgramID,
+		},
+		RecentBlockHash: "9rAtxuhtKn8qagc3UtZFyhLrw5zkh6etv43TibaXuSKo",
+		Instructions: []CompiledInstruction{
+			{
+				ProgramIDIndex: 3,
+				Accounts:       []int{0, 1, 2, 4, 5},
+				Data:           []byte{1},
+			},
+		},
+		AddressLookupTables: []CompiledAddressLookupTable{
+			{
+				AccountKey:      alt.Key,
+				WritableIndexes: []uint8{2},
+				ReadonlyIndexes: []uint8{0},
+			},
+		},
+	}
+	want := []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"), IsSigner: true, IsWritable: true},
+				{PubKey: common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz"), IsSigner: true, IsWritable: false},
+				{PubKey: common.PublicKeyFromString("DdxNp4kbPkYjK7hR3ESrmGXeACDsSYx4V4qWKAn5Tu6x"), IsSigner: false, IsWritable: true},
+				{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+				{PubKey: common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i"), IsSigner: false, IsWritable: false},
+			},
+			Data: []byte{1},
+		},
+	}
+
+	got, err := m.DecompileInstructionsWithAddressLookupTables([]AddressLookupTableAccount{alt})
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = m.DecompileInstructionsWithLoadedAddresses(LoadedAddresses{
+		Writable: []common.PublicKey{common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")},
+		Readonly: []common.PublicKey{common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = m.DecompileInstructionsWithAddressLookupTables(nil)
+	assert.EqualError(t, err, "address lookup table HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY not found")
+
+	_, err = m.DecompileInstructionsWithAddressLookupTables([]AddressLookupTableAccount{{Key: alt.Key, Addresses: alt.Addresses[:2]}})
+	assert.EqualError(t, err, "index 2 is out of range of address lookup table HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY")
+
+	_, err = m.DecompileInstructionsWithLoadedAddresses(LoadedAddresses{})
+	assert.EqualError(t, err, "message loads 2 addresses but got 0")
+
+	m.Instructions[0].Accounts = append(m.Instructions[0].Accounts, 6)
+	_, err = m.DecompileInstructionsWithAddressLookupTables([]AddressLookupTableAccount{alt})
+	assert.EqualError(t, err, "instruction 0: account index 6 is out of range")
+
+	m.Instructions[0].ProgramIDIndex = 4
+	_, err = m.DecompileInstructionsWithAddressLookupTables([]AddressLookupTableAccount{alt})
+	assert.EqualError(t, err, "instruction 0: program id index 4 is out of range")
+
+	m.Version = "v1"
+	_, err = m.DecompileInstructionsWithLoadedAddresses(LoadedAddresses{})
+	assert.ErrorIs(t, err, ErrUnsupportedMessageVersion)
+}
+
 func TestNewMessage(t *testing.T) {
 	type args struct {
 		param NewMessageParam
@@ -844,6 +947,24 @@ func TestMessageDeserialize(t *testing.T) {
 			want: Message{},
 			err:  fmt.Errorf("message header #1 parse error: data is empty"),
 		},
+		{
+			name: "unsupported version",
+			args: args{messageData: []byte{129, 1, 0, 0}},
+			want: Message{},
+			err:  fmt.Errorf("unsupported message version: v1"),
+		},
+		{
+			name: "truncated instruction data",
+			args: args{messageData: append(append([]byte{1, 0, 0, 1}, make([]byte, 64)...), 1, 0, 0, 100)},
+			want: Message{},
+			err:  fmt.Errorf("parse instruction #1 data error: data is too short, need 100 bytes, remaining: 0"),
+		},
+		{
+			name: "huge instruction count",
+			args: args{messageData: append(append([]byte{1, 0, 0, 1}, make([]byte, 64)...), 255, 255, 3, 0)},
+			want: Message{},
+			err:  fmt.Errorf("parse instruction count error: 65535 instructions exceed the remaining 1 bytes"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
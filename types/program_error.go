@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/blocto/solana-go-sdk/common"
+)
+
+// ProgramError is a named custom error of a program
+type ProgramError struct {
+	Program string
+	Code    uint32
+	Name    string
+	Message string
+}
+
+func (e *ProgramError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Program, e.Message)
+}
+
+// ProgramErrorCatalog maps custom error codes of programs to named errors.
+// it is safe for concurrent use.
+type ProgramErrorCatalog struct {
+	mu       sync.RWMutex
+	programs map[common.PublicKey]map[uint32]*ProgramError
+}
+
+func NewProgramErrorCatalog() *ProgramErrorCatalog {
+	return &ProgramErrorCatalog{
+		programs: map[common.PublicKey]map[uint32]*ProgramError{},
+	}
+}
+
+// Register adds errors of the program, an error with a registered code replaces the old one
+func (c *ProgramErrorCatalog) Register(programId common.PublicKey, errs []ProgramError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.programs[programId]
+	if !ok {
+		m = make(map[uint32]*ProgramError, len(errs))
+		c.programs[programId] = m
+	}
+	for i := range errs {
+		e := errs[i]
+		m[e.Code] = &e
+	}
+}
+
+// Lookup returns the named error of the code
+func (c *ProgramErrorCatalog) Lookup(programId common.PublicKey, code uint32) (*ProgramError, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.programs[programId][code]
+	return e, ok
+}
+
+// Resolve fills InstructionError.ProgramError of a custom instruction error by the
+// program of the failed instruction. a custom code returned from a cpi is resolved
+// by the outer program too since the runtime doesn't report which program failed.
+func (c *ProgramErrorCatalog) Resolve(txErr *TransactionError, message Message) {
+	if txErr == nil || txErr.InstructionError == nil || txErr.InstructionError.Type != InstructionErrorCustom {
+		return
+	}
+	if int(txErr.InstructionIndex) >= len(message.Instructions) {
+		return
+	}
+	// program ids are always static keys
+	programIDIndex := message.Instructions[txErr.InstructionIndex].ProgramIDIndex
+	if programIDIndex < 0 || programIDIndex >= len(message.Accounts) {
+		return
+	}
+	if e, ok := c.Lookup(message.Accounts[programIDIndex], txErr.InstructionError.Custom); ok {
+		txErr.InstructionError.ProgramError = e
+	}
+}
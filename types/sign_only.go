@@ -0,0 +1,324 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/mr-tron/base58"
+)
+
+var (
+	ErrSignOnlyBlockhashMismatch = errors.New("sign only blockhash mismatch")
+	ErrSignOnlyMessageMismatch   = errors.New("sign only message mismatch")
+)
+
+// TransactionFromBase58 deserializes a base58 tx which may be partially signed
+func TransactionFromBase58(s string) (Transaction, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to base58 decode tx, err: %v", err)
+	}
+	return TransactionDeserialize(b)
+}
+
+// TransactionFromBase64 deserializes a base64 tx which may be partially signed
+func TransactionFromBase64(s string) (Transaction, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to base64 decode tx, err: %v", err)
+	}
+	return TransactionDeserialize(b)
+}
+
+// ToBase58 packs a partially signed tx into base58
+func (tx *Transaction) ToBase58() (string, error) {
+	b, err := tx.SerializePartiallySigned()
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(b), nil
+}
+
+// ToBase64 packs a partially signed tx into base64
+func (tx *Transaction) ToBase64() (string, error) {
+	b, err := tx.SerializePartiallySigned()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Presigner is a signature made offline, the cli writes it as `pubkey=signature`.
+// it is a Signer which only signs the message it was made for.
+type Presigner struct {
+	PublicKey common.PublicKey
+	Signature Signature
+}
+
+// ParsePresigner parses `pubkey=signature`
+func ParsePresigner(s string) (Presigner, error) {
+	pubkey, signature, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok {
+		return Presigner{}, fmt.Errorf("invalid presigner %q, expected: pubkey=signature", s)
+	}
+	pubkeyBytes, err := base58.Decode(pubkey)
+	if err != nil || len(pubkeyBytes) != common.PublicKeyLength {
+		return Presigner{}, fmt.Errorf("invalid presigner pubkey %q", pubkey)
+	}
+	sig, err := base58.Decode(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return Presigner{}, fmt.Errorf("invalid presigner signature %q", signature)
+	}
+	return Presigner{
+		PublicKey: common.PublicKeyFromBytes(pubkeyBytes),
+		Signature: sig,
+	}, nil
+}
+
+func (p Presigner) String() string {
+	return fmt.Sprintf("%v=%v", p.PublicKey.ToBase58(), base58.Encode(p.Signature))
+}
+
+func (p Presigner) Public() common.PublicKey {
+	return p.PublicKey
+}
+
+// SignMessage returns the signature if it is made for the message
+func (p Presigner) SignMessage(_ context.Context, message []byte) ([]byte, error) {
+	if !ed25519.Verify(p.PublicKey.Bytes(), message, p.Signature) {
+		return nil, fmt.Errorf("%w, signer: %v", ErrTransactionInvalidSignature, p.PublicKey)
+	}
+	return p.Signature, nil
+}
+
+// SignOnlyData is the output of `solana ... --sign-only`
+type SignOnlyData struct {
+	Blockhash string
+	// Message is the base64 message, the cli only outputs it with --dump-transaction-message
+	Message string
+	Signers []Presigner
+	Absent  []common.PublicKey
+	BadSig  []common.PublicKey
+}
+
+type signOnlyDataJSON struct {
+	Blockhash string   `json:"blockhash"`
+	Message   string   `json:"message,omitempty"`
+	Signers   []string `json:"signers"`
+	Absent    []string `json:"absent,omitempty"`
+	BadSig    []string `json:"badSig,omitempty"`
+}
+
+// SignOnlyData exports the signatures of the tx in the cli sign only format.
+// invalid signatures are listed in BadSig.
+func (tx *Transaction) SignOnlyData() (SignOnlyData, error) {
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return SignOnlyData{}, fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	output := SignOnlyData{
+		Blockhash: tx.Message.RecentBlockHash,
+		Message:   base64.StdEncoding.EncodeToString(data),
+		Signers:   []Presigner{},
+	}
+	for i, signer := range tx.RequiredSigners() {
+		switch {
+		case i >= len(tx.Signatures) || tx.Signatures[i].IsZero():
+			output.Absent = append(output.Absent, signer)
+		case !ed25519.Verify(signer.Bytes(), data, tx.Signatures[i]):
+			output.BadSig = append(output.BadSig, signer)
+		default:
+			output.Signers = append(output.Signers, Presigner{PublicKey: signer, Signature: tx.Signatures[i]})
+		}
+	}
+	return output, nil
+}
+
+// MergeSignOnlyData adds signatures of the sign only data into the tx. the blockhash
+// (and the message if it exists) must match the tx. nothing is added if any signature
+// doesn't verify.
+func (tx *Transaction) MergeSignOnlyData(data SignOnlyData) error {
+	if data.Blockhash != tx.Message.RecentBlockHash {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrSignOnlyBlockhashMismatch, tx.Message.RecentBlockHash, data.Blockhash)
+	}
+	if data.Message != "" {
+		message, err := tx.Message.Serialize()
+		if err != nil {
+			return fmt.Errorf("failed to serialize message, err: %v", err)
+		}
+		if data.Message != base64.StdEncoding.EncodeToString(message) {
+			return ErrSignOnlyMessageMismatch
+		}
+	}
+	signers := make([]Signer, 0, len(data.Signers))
+	for _, presigner := range data.Signers {
+		signers = append(signers, presigner)
+	}
+	return tx.Sign(context.Background(), signers...)
+}
+
+// MergeTransactionSignatures adds signatures of other copies of the same tx, e.g. each
+// offline signer returns its own partially signed tx. every added signature is verified.
+func (tx *Transaction) MergeTransactionSignatures(others ...Transaction) error {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	signers := []Signer{}
+	for _, other := range others {
+		otherMessage, err := other.Message.Serialize()
+		if err != nil {
+			return fmt.Errorf("failed to serialize message, err: %v", err)
+		}
+		if !bytes.Equal(message, otherMessage) {
+			return ErrSignOnlyMessageMismatch
+		}
+		for i, signer := range other.RequiredSigners() {
+			if i < len(other.Signatures) && !other.Signatures[i].IsZero() {
+				signers = append(signers, Presigner{PublicKey: signer, Signature: other.Signatures[i]})
+			}
+		}
+	}
+	return tx.Sign(context.Background(), signers...)
+}
+
+func (d SignOnlyData) MarshalJSON() ([]byte, error) {
+	output := signOnlyDataJSON{
+		Blockhash: d.Blockhash,
+		Message:   d.Message,
+		Signers:   make([]string, 0, len(d.Signers)),
+	}
+	for _, signer := range d.Signers {
+		output.Signers = append(output.Signers, signer.String())
+	}
+	for _, pubkey := range d.Absent {
+		output.Absent = append(output.Absent, pubkey.ToBase58())
+	}
+	for _, pubkey := range d.BadSig {
+		output.BadSig = append(output.BadSig, pubkey.ToBase58())
+	}
+	return json.Marshal(output)
+}
+
+func (d *SignOnlyData) UnmarshalJSON(b []byte) error {
+	var input signOnlyDataJSON
+	if err := json.Unmarshal(b, &input); err != nil {
+		return err
+	}
+	output := SignOnlyData{Blockhash: input.Blockhash, Message: input.Message}
+	for _, s := range input.Signers {
+		presigner, err := ParsePresigner(s)
+		if err != nil {
+			return err
+		}
+		output.Signers = append(output.Signers, presigner)
+	}
+	var err error
+	if output.Absent, err = parsePublicKeys(input.Absent); err != nil {
+		return err
+	}
+	if output.BadSig, err = parsePublicKeys(input.BadSig); err != nil {
+		return err
+	}
+	*d = output
+	return nil
+}
+
+// String is the cli display format
+func (d SignOnlyData) String() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "Blockhash: %v\n", d.Blockhash)
+	if d.Message != "" {
+		fmt.Fprintf(&sb, "Transaction Message: %v\n", d.Message)
+	}
+	if len(d.Signers) > 0 {
+		sb.WriteString("Signers (Pubkey=Signature):\n")
+		for _, signer := range d.Signers {
+			fmt.Fprintf(&sb, " %v\n", signer)
+		}
+	}
+	if len(d.Absent) > 0 {
+		sb.WriteString("Absent Signers (Pubkey):\n")
+		for _, pubkey := range d.Absent {
+			fmt.Fprintf(&sb, " %v\n", pubkey.ToBase58())
+		}
+	}
+	if len(d.BadSig) > 0 {
+		sb.WriteString("Bad Signatures (Pubkey):\n")
+		for _, pubkey := range d.BadSig {
+			fmt.Fprintf(&sb, " %v\n", pubkey.ToBase58())
+		}
+	}
+	return sb.String()
+}
+
+// ParseSignOnlyData parses the cli sign only output, both the json and the display format
+func ParseSignOnlyData(s string) (SignOnlyData, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") {
+		var data SignOnlyData
+		if err := json.Unmarshal([]byte(s), &data); err != nil {
+			return SignOnlyData{}, fmt.Errorf("failed to parse sign only json, err: %v", err)
+		}
+		return data, nil
+	}
+
+	var data SignOnlyData
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "Blockhash:"):
+			data.Blockhash = strings.TrimSpace(strings.TrimPrefix(line, "Blockhash:"))
+		case strings.HasPrefix(line, "Transaction Message:"):
+			data.Message = strings.TrimSpace(strings.TrimPrefix(line, "Transaction Message:"))
+		case line == "Signers (Pubkey=Signature):", line == "Absent Signers (Pubkey):", line == "Bad Signatures (Pubkey):":
+			section = line
+		case section == "Signers (Pubkey=Signature):":
+			presigner, err := ParsePresigner(line)
+			if err != nil {
+				return SignOnlyData{}, err
+			}
+			data.Signers = append(data.Signers, presigner)
+		case section == "Absent Signers (Pubkey):" || section == "Bad Signatures (Pubkey):":
+			pubkeys, err := parsePublicKeys([]string{line})
+			if err != nil {
+				return SignOnlyData{}, err
+			}
+			if section == "Absent Signers (Pubkey):" {
+				data.Absent = append(data.Absent, pubkeys...)
+			} else {
+				data.BadSig = append(data.BadSig, pubkeys...)
+			}
+		default:
+			return SignOnlyData{}, fmt.Errorf("unexpected sign only line %q", line)
+		}
+	}
+	if data.Blockhash == "" {
+		return SignOnlyData{}, errors.New("sign only data has no blockhash")
+	}
+	return data, nil
+}
+
+func parsePublicKeys(keys []string) ([]common.PublicKey, error) {
+	var output []common.PublicKey
+	for _, key := range keys {
+		b, err := base58.Decode(key)
+		if err != nil || len(b) != common.PublicKeyLength {
+			return nil, fmt.Errorf("invalid pubkey %q", key)
+		}
+		output = append(output, common.PublicKeyFromBytes(b))
+	}
+	return output, nil
+}
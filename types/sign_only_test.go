@@ -0,0 +1,136 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignOnly(t *testing.T) {
+	feePayer := NewAccount()
+	custodian1 := NewAccount()
+	custodian2 := NewAccount()
+	msg := NewMessage(NewMessageParam{
+		FeePayer: feePayer.PublicKey,
+		Instructions: []Instruction{
+			{
+				ProgramID: common.PublicKeyFromString("CustomProgram111111111111111111111111111111"),
+				Accounts: []AccountMeta{
+					{PubKey: custodian1.PublicKey, IsSigner: true, IsWritable: true},
+					{PubKey: custodian2.PublicKey, IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{},
+			},
+		},
+		RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	serMsg, err := msg.Serialize()
+	require.NoError(t, err)
+
+	// the coordinator exports the unsigned tx
+	unsigned := NewUnsignedTransaction(msg)
+	blob, err := unsigned.ToBase64()
+	require.NoError(t, err)
+
+	// an offline signer imports, signs and exports the sign only data
+	offline, err := TransactionFromBase64(blob)
+	require.NoError(t, err)
+	require.NoError(t, offline.AddSignatureForPubkey(custodian1.PublicKey, custodian1.Sign(serMsg)))
+	data, err := offline.SignOnlyData()
+	require.NoError(t, err)
+	assert.Equal(t, SignOnlyData{
+		Blockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		Message:   base64.StdEncoding.EncodeToString(serMsg),
+		Signers:   []Presigner{{PublicKey: custodian1.PublicKey, Signature: custodian1.Sign(serMsg)}},
+		Absent:    []common.PublicKey{feePayer.PublicKey, custodian2.PublicKey},
+	}, data)
+
+	t.Run("display format", func(t *testing.T) {
+		parsed, err := ParseSignOnlyData(data.String())
+		require.NoError(t, err)
+		assert.Equal(t, data, parsed)
+	})
+
+	t.Run("json format", func(t *testing.T) {
+		b, err := json.Marshal(data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"blockhash": "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			"message": "`+base64.StdEncoding.EncodeToString(serMsg)+`",
+			"signers": ["`+custodian1.PublicKey.ToBase58()+"="+base58.Encode(custodian1.Sign(serMsg))+`"],
+			"absent": ["`+feePayer.PublicKey.ToBase58()+`", "`+custodian2.PublicKey.ToBase58()+`"]
+		}`, string(b))
+		parsed, err := ParseSignOnlyData(string(b))
+		require.NoError(t, err)
+		assert.Equal(t, data, parsed)
+	})
+
+	t.Run("cli output without message", func(t *testing.T) {
+		parsed, err := ParseSignOnlyData(`
+Blockhash: FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5
+Signers (Pubkey=Signature):
+  ` + custodian2.PublicKey.ToBase58() + "=" + base58.Encode(custodian2.Sign(serMsg)) + `
+Absent Signers (Pubkey):
+  ` + feePayer.PublicKey.ToBase58() + `
+  ` + custodian1.PublicKey.ToBase58() + `
+`)
+		require.NoError(t, err)
+
+		tx := NewUnsignedTransaction(msg)
+		require.NoError(t, tx.MergeSignOnlyData(data))
+		require.NoError(t, tx.MergeSignOnlyData(parsed))
+		require.NoError(t, tx.AddSignatureForPubkey(feePayer.PublicKey, feePayer.Sign(serMsg)))
+		assert.Empty(t, tx.MissingSigners())
+		assert.NoError(t, tx.VerifySignatures())
+	})
+
+	t.Run("merge transactions", func(t *testing.T) {
+		other, err := TransactionFromBase64(blob)
+		require.NoError(t, err)
+		require.NoError(t, other.AddSignatureForPubkey(custodian2.PublicKey, custodian2.Sign(serMsg)))
+		otherBlob, err := other.ToBase58()
+		require.NoError(t, err)
+		other, err = TransactionFromBase58(otherBlob)
+		require.NoError(t, err)
+
+		tx := NewUnsignedTransaction(msg)
+		require.NoError(t, tx.MergeTransactionSignatures(offline, other))
+		assert.Equal(t, []common.PublicKey{feePayer.PublicKey}, tx.MissingSigners())
+		assert.NoError(t, tx.VerifySignatures())
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		tx := NewUnsignedTransaction(msg)
+
+		bad := data
+		bad.Message = ""
+		bad.Signers = []Presigner{{PublicKey: custodian2.PublicKey, Signature: custodian1.Sign(serMsg)}}
+		assert.ErrorIs(t, tx.MergeSignOnlyData(bad), ErrTransactionInvalidSignature)
+
+		bad = data
+		bad.Blockhash = "11111111111111111111111111111111"
+		assert.ErrorIs(t, tx.MergeSignOnlyData(bad), ErrSignOnlyBlockhashMismatch)
+
+		otherMsg := msg
+		otherMsg.RecentBlockHash = "11111111111111111111111111111111"
+		assert.ErrorIs(t, tx.MergeTransactionSignatures(NewUnsignedTransaction(otherMsg)), ErrSignOnlyMessageMismatch)
+
+		assert.Equal(t, NewUnsignedTransaction(msg), tx)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := ParsePresigner("abc")
+		assert.Error(t, err)
+		_, err = ParseSignOnlyData("Signers (Pubkey=Signature):\n abc=def\n")
+		assert.Error(t, err)
+		_, err = ParseSignOnlyData("hello")
+		assert.Error(t, err)
+		_, err = TransactionFromBase58("0OIl")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blocto/solana-go-sdk/common"
+)
+
+var (
+	ErrSignerInvalidSignature = errors.New("signer returned an invalid signature")
+)
+
+// Signer signs messages for a public key. the private key doesn't need to be in
+// memory, e.g. an implementation can call an HSM or a KMS service.
+type Signer interface {
+	Public() common.PublicKey
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// Public returns the public key of the account
+func (a Account) Public() common.PublicKey {
+	return a.PublicKey
+}
+
+// SignMessage signs the message by the private key, it never fails
+func (a Account) SignMessage(_ context.Context, message []byte) ([]byte, error) {
+	return a.Sign(message), nil
+}
+
+// SignersFromAccounts converts accounts to signers
+func SignersFromAccounts(accounts ...Account) []Signer {
+	signers := make([]Signer, 0, len(accounts))
+	for _, account := range accounts {
+		signers = append(signers, account)
+	}
+	return signers
+}
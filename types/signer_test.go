@@ -0,0 +1,126 @@
+package types
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// remoteSigner acts like a kms, the key never leaves it
+type remoteSigner struct {
+	pubkey common.PublicKey
+	sign   func(message []byte) ([]byte, error)
+}
+
+func newRemoteSigner() remoteSigner {
+	account := NewAccount()
+	return remoteSigner{
+		pubkey: account.PublicKey,
+		sign: func(message []byte) ([]byte, error) {
+			return ed25519.Sign(account.PrivateKey, message), nil
+		},
+	}
+}
+
+func (s remoteSigner) Public() common.PublicKey {
+	return s.pubkey
+}
+
+func (s remoteSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.sign(message)
+}
+
+func TestNewTransactionWithSigners(t *testing.T) {
+	feePayer := NewAccount()
+	remote := newRemoteSigner()
+	msg := NewMessage(NewMessageParam{
+		FeePayer: feePayer.PublicKey,
+		Instructions: []Instruction{
+			{
+				ProgramID: common.PublicKeyFromString("CustomProgram111111111111111111111111111111"),
+				Accounts: []AccountMeta{
+					{PubKey: remote.Public(), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{},
+			},
+		},
+		RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	serMsg, err := msg.Serialize()
+	require.NoError(t, err)
+
+	t.Run("account and remote signer", func(t *testing.T) {
+		tx, err := NewTransactionWithSigners(context.Background(), NewTransactionWithSignersParam{
+			Message: msg,
+			Signers: []Signer{feePayer, remote},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, Signature(feePayer.Sign(serMsg)), tx.Signatures[0])
+		assert.True(t, ed25519.Verify(remote.Public().Bytes(), serMsg, tx.Signatures[1]))
+	})
+
+	t.Run("sign later", func(t *testing.T) {
+		tx, err := NewTransaction(NewTransactionParam{Message: msg, Signers: []Account{feePayer}})
+		require.NoError(t, err)
+		assert.Equal(t, Signature(make([]byte, 64)), tx.Signatures[1])
+
+		require.NoError(t, tx.Sign(context.Background(), remote))
+		assert.Equal(t, Signature(feePayer.Sign(serMsg)), tx.Signatures[0])
+		assert.True(t, ed25519.Verify(remote.Public().Bytes(), serMsg, tx.Signatures[1]))
+	})
+
+	t.Run("signer fails", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		tx := Transaction{Message: msg}
+		err := tx.Sign(ctx, feePayer, remote)
+		assert.ErrorIs(t, err, context.Canceled)
+		// nothing is put if any signer fails
+		assert.Equal(t, []Signature{make([]byte, 64), make([]byte, 64)}, tx.Signatures)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		broken := remote
+		broken.sign = func(message []byte) ([]byte, error) {
+			return feePayer.Sign(message), nil
+		}
+		_, err := NewTransactionWithSigners(context.Background(), NewTransactionWithSignersParam{
+			Message: msg,
+			Signers: []Signer{broken},
+		})
+		assert.ErrorIs(t, err, ErrSignerInvalidSignature)
+	})
+
+	t.Run("not a signer", func(t *testing.T) {
+		_, err := NewTransactionWithSigners(context.Background(), NewTransactionWithSignersParam{
+			Message: msg,
+			Signers: []Signer{newRemoteSigner()},
+		})
+		assert.ErrorIs(t, err, ErrTransactionAddNotNecessarySignatures)
+	})
+
+	t.Run("remote error", func(t *testing.T) {
+		broken := remote
+		broken.sign = func(message []byte) ([]byte, error) {
+			return nil, errors.New("kms unavailable")
+		}
+		err := (&Transaction{Message: msg}).Sign(context.Background(), broken)
+		assert.EqualError(t, err, "failed to sign by "+remote.Public().ToBase58()+", err: kms unavailable")
+	})
+}
+
+func TestSignersFromAccounts(t *testing.T) {
+	a, b := NewAccount(), NewAccount()
+	signers := SignersFromAccounts(a, b)
+	require.Len(t, signers, 2)
+	assert.Equal(t, a.PublicKey, signers[0].Public())
+	assert.Equal(t, b.PublicKey, signers[1].Public())
+}
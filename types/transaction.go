@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/binary"
 	"errors"
@@ -12,10 +13,21 @@ import (
 
 var (
 	ErrTransactionAddNotNecessarySignatures = errors.New("add not necessary signatures")
+	ErrTransactionInvalidSignature          = errors.New("invalid signature")
 )
 
 type Signature []byte
 
+// IsZero reports whether the signature is an unfilled slot
+func (s Signature) IsZero() bool {
+	for _, b := range s {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 type Transaction struct {
 	Signatures []Signature
 	Message    Message
@@ -28,32 +40,65 @@ type NewTransactionParam struct {
 
 // NewTransaction create a new tx by message and signer. it will reserve signatures slot.
 func NewTransaction(param NewTransactionParam) (Transaction, error) {
-	signatures := make([]Signature, 0, param.Message.Header.NumRequireSignatures)
-	for i := uint8(0); i < param.Message.Header.NumRequireSignatures; i++ {
-		signatures = append(signatures, make([]byte, 64))
+	return NewTransactionWithSigners(context.Background(), NewTransactionWithSignersParam{
+		Message: param.Message,
+		Signers: SignersFromAccounts(param.Signers...),
+	})
+}
+
+type NewTransactionWithSignersParam struct {
+	Message Message
+	Signers []Signer
+}
+
+// NewTransactionWithSigners create a new tx by message and signers which may sign remotely.
+// it will reserve signatures slot.
+func NewTransactionWithSigners(ctx context.Context, param NewTransactionWithSignersParam) (Transaction, error) {
+	tx := Transaction{Message: param.Message}
+	if err := tx.Sign(ctx, param.Signers...); err != nil {
+		return Transaction{}, err
+	}
+	return tx, nil
+}
+
+// Sign signs the tx by the signers and puts signatures into their slots. it reserves
+// zeroed slots for missing signatures.
+func (tx *Transaction) Sign(ctx context.Context, signers ...Signer) error {
+	tx.fillSignatureSlots()
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) {
+		return fmt.Errorf("signature count mismatch, expected: %v, got: %v", tx.Message.Header.NumRequireSignatures, len(tx.Signatures))
 	}
 
 	m := map[common.PublicKey]uint8{}
-	for i := uint8(0); i < param.Message.Header.NumRequireSignatures; i++ {
-		m[param.Message.Accounts[i]] = i
+	for i := uint8(0); i < tx.Message.Header.NumRequireSignatures; i++ {
+		m[tx.Message.Accounts[i]] = i
 	}
 
-	data, err := param.Message.Serialize()
+	data, err := tx.Message.Serialize()
 	if err != nil {
-		return Transaction{}, fmt.Errorf("failed to serialize message, err: %v", err)
+		return fmt.Errorf("failed to serialize message, err: %v", err)
 	}
-	for _, signer := range param.Signers {
-		idx, ok := m[signer.PublicKey]
+	// signatures are put after every signer succeeds so a failure leaves the tx untouched
+	sigs := make(map[uint8]Signature, len(signers))
+	for _, signer := range signers {
+		pubkey := signer.Public()
+		idx, ok := m[pubkey]
 		if !ok {
-			return Transaction{}, fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, signer.PublicKey)
+			return fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, pubkey)
+		}
+		sig, err := signer.SignMessage(ctx, data)
+		if err != nil {
+			return fmt.Errorf("failed to sign by %v, err: %w", pubkey, err)
 		}
-		signatures[idx] = signer.Sign(data)
+		if !ed25519.Verify(pubkey.Bytes(), data, sig) {
+			return fmt.Errorf("%w, signer: %v", ErrSignerInvalidSignature, pubkey)
+		}
+		sigs[idx] = sig
 	}
-
-	return Transaction{
-		Signatures: signatures,
-		Message:    param.Message,
-	}, nil
+	for idx, sig := range sigs {
+		tx.Signatures[idx] = sig
+	}
+	return nil
 }
 
 // AddSignature will add or replace signature into the correct order signature's slot.
@@ -72,6 +117,110 @@ func (tx *Transaction) AddSignature(sig []byte) error {
 	return fmt.Errorf("%w, no match signer", ErrTransactionAddNotNecessarySignatures)
 }
 
+// NewUnsignedTransaction create a tx without any signature, all signatures slot are zeroed.
+func NewUnsignedTransaction(message Message) Transaction {
+	signatures := make([]Signature, 0, message.Header.NumRequireSignatures)
+	for i := uint8(0); i < message.Header.NumRequireSignatures; i++ {
+		signatures = append(signatures, make([]byte, 64))
+	}
+	return Transaction{
+		Signatures: signatures,
+		Message:    message,
+	}
+}
+
+// RequiredSigners returns pubkeys which need to sign the tx, in signatures slot order.
+func (tx *Transaction) RequiredSigners() []common.PublicKey {
+	signers := make([]common.PublicKey, 0, tx.Message.Header.NumRequireSignatures)
+	for i := uint8(0); i < tx.Message.Header.NumRequireSignatures && int(i) < len(tx.Message.Accounts); i++ {
+		signers = append(signers, tx.Message.Accounts[i])
+	}
+	return signers
+}
+
+// MissingSigners returns required signers whose signature slot is still empty.
+func (tx *Transaction) MissingSigners() []common.PublicKey {
+	missing := []common.PublicKey{}
+	for i, signer := range tx.RequiredSigners() {
+		if i >= len(tx.Signatures) || tx.Signatures[i].IsZero() {
+			missing = append(missing, signer)
+		}
+	}
+	return missing
+}
+
+// AddSignatureForPubkey puts the signature into the slot of the pubkey after verifying it.
+func (tx *Transaction) AddSignatureForPubkey(pubkey common.PublicKey, sig []byte) error {
+	idx := -1
+	for i, signer := range tx.RequiredSigners() {
+		if signer == pubkey {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, pubkey)
+	}
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	if !ed25519.Verify(pubkey.Bytes(), data, sig) {
+		return fmt.Errorf("%w, signer: %v", ErrTransactionInvalidSignature, pubkey)
+	}
+	tx.fillSignatureSlots()
+	tx.Signatures[idx] = sig
+	return nil
+}
+
+// VerifySignatures verifies every present signature against the message, empty
+// slots are skipped. use MissingSigners to check whether the tx is fully signed.
+func (tx *Transaction) VerifySignatures() error {
+	if len(tx.Signatures) > int(tx.Message.Header.NumRequireSignatures) {
+		return fmt.Errorf("too many signatures, expected: %v, got: %v", tx.Message.Header.NumRequireSignatures, len(tx.Signatures))
+	}
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	signers := tx.RequiredSigners()
+	for i, sig := range tx.Signatures {
+		if sig.IsZero() {
+			continue
+		}
+		if i >= len(signers) || !ed25519.Verify(signers[i].Bytes(), data, sig) {
+			return fmt.Errorf("%w, index: %v", ErrTransactionInvalidSignature, i)
+		}
+	}
+	return nil
+}
+
+// SerializePartiallySigned packs a tx which may miss some signatures, missing slots
+// are zeroed. present signatures must be valid.
+func (tx *Transaction) SerializePartiallySigned() ([]byte, error) {
+	if err := tx.VerifySignatures(); err != nil {
+		return nil, err
+	}
+	partial := Transaction{
+		Signatures: append([]Signature{}, tx.Signatures...),
+		Message:    tx.Message,
+	}
+	partial.fillSignatureSlots()
+	return partial.Serialize()
+}
+
+// fillSignatureSlots reserves zeroed slots for missing signatures
+func (tx *Transaction) fillSignatureSlots() {
+	for len(tx.Signatures) < int(tx.Message.Header.NumRequireSignatures) {
+		tx.Signatures = append(tx.Signatures, nil)
+	}
+	for i, sig := range tx.Signatures {
+		if len(sig) == 0 {
+			tx.Signatures[i] = make([]byte, 64)
+		}
+	}
+}
+
 // Serialize pack tx into byte array
 func (tx *Transaction) Serialize() ([]byte, error) {
 	if len(tx.Signatures) == 0 || len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) {
@@ -103,12 +252,12 @@ func TransactionDeserialize(tx []byte) (Transaction, error) {
 	if signatureCount < 1 {
 		return Transaction{}, errors.New("signature count must be greater than or equal to 1")
 	}
-	if len(tx) < int(signatureCount)*64 {
+	if signatureCount > uint64(len(tx)/64) {
 		return Transaction{}, errors.New("parse signature error")
 	}
 	signatures := make([]Signature, 0, signatureCount)
 	for i := 0; i < int(signatureCount); i++ {
-		signatures = append(signatures, tx[:64])
+		signatures = append(signatures, tx[:64:64])
 		tx = tx[64:]
 	}
 
@@ -147,3 +296,23 @@ func parseUvarint(tx *[]byte) (uint64, error) {
 	*tx = (*tx)[n:]
 	return u, nil
 }
+
+func parseU8(data *[]byte) (uint8, error) {
+	if len(*data) == 0 {
+		return 0, errors.New("data is empty")
+	}
+	u := (*data)[0]
+	*data = (*data)[1:]
+	return u, nil
+}
+
+// parseBytes returns the next n bytes, the capacity is capped so appending to them
+// doesn't overwrite the rest of the data
+func parseBytes(data *[]byte, n uint64) ([]byte, error) {
+	if n > uint64(len(*data)) {
+		return nil, fmt.Errorf("data is too short, need %v bytes, remaining: %v", n, len(*data))
+	}
+	b := (*data)[:n:n]
+	*data = (*data)[n:]
+	return b, nil
+}
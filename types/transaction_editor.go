@@ -0,0 +1,145 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrTransactionEditorSignedTransactionModified  = errors.New("signed transaction is modified, invalidate signatures first")
+	ErrTransactionEditorInstructionIndexOutOfRange = errors.New("instruction index out of range")
+)
+
+// Decompile turns the message back into the param which compiles it. tables should
+// contain every lookup table the message uses.
+func (m *Message) Decompile(tables []AddressLookupTableAccount) (NewMessageParam, error) {
+	if len(m.Accounts) == 0 {
+		return NewMessageParam{}, errors.New("message has no fee payer")
+	}
+	instructions, err := m.DecompileInstructionsWithAddressLookupTables(tables)
+	if err != nil {
+		return NewMessageParam{}, err
+	}
+
+	var usedTables []AddressLookupTableAccount
+	for _, lookup := range m.AddressLookupTables {
+		for _, table := range tables {
+			if table.Key == lookup.AccountKey {
+				usedTables = append(usedTables, table)
+				break
+			}
+		}
+	}
+	return NewMessageParam{
+		FeePayer:                   m.Accounts[0],
+		Instructions:               instructions,
+		RecentBlockhash:            m.RecentBlockHash,
+		AddressLookupTableAccounts: usedTables,
+		Version:                    m.Version,
+	}, nil
+}
+
+// TransactionEditor edits instructions of an existing transaction then recompiles it.
+// a signed transaction can't be recompiled into a different message until its
+// signatures are invalidated by InvalidateSignatures.
+type TransactionEditor struct {
+	NewMessageParam
+
+	original      Transaction
+	originalParam NewMessageParam
+	invalidated   bool
+}
+
+// NewTransactionEditor decompiles the transaction, tables should contain every lookup
+// table the message uses.
+func NewTransactionEditor(tx Transaction, tables []AddressLookupTableAccount) (*TransactionEditor, error) {
+	param, err := tx.Message.Decompile(tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompile message, err: %w", err)
+	}
+	originalParam := param
+	originalParam.Instructions = append([]Instruction{}, param.Instructions...)
+	return &TransactionEditor{
+		NewMessageParam: param,
+		original:        tx,
+		originalParam:   originalParam,
+	}, nil
+}
+
+// InsertInstructions inserts instructions before the index, the index can be the length
+// to append
+func (e *TransactionEditor) InsertInstructions(index int, instructions ...Instruction) error {
+	if index < 0 || index > len(e.Instructions) {
+		return fmt.Errorf("%w, index: %v", ErrTransactionEditorInstructionIndexOutOfRange, index)
+	}
+	output := make([]Instruction, 0, len(e.Instructions)+len(instructions))
+	output = append(output, e.Instructions[:index]...)
+	output = append(output, instructions...)
+	output = append(output, e.Instructions[index:]...)
+	e.Instructions = output
+	return nil
+}
+
+// RemoveInstruction removes the instruction at the index
+func (e *TransactionEditor) RemoveInstruction(index int) error {
+	if index < 0 || index >= len(e.Instructions) {
+		return fmt.Errorf("%w, index: %v", ErrTransactionEditorInstructionIndexOutOfRange, index)
+	}
+	output := make([]Instruction, 0, len(e.Instructions)-1)
+	output = append(output, e.Instructions[:index]...)
+	output = append(output, e.Instructions[index+1:]...)
+	e.Instructions = output
+	return nil
+}
+
+// ReplaceInstruction replaces the instruction at the index
+func (e *TransactionEditor) ReplaceInstruction(index int, instruction Instruction) error {
+	if index < 0 || index >= len(e.Instructions) {
+		return fmt.Errorf("%w, index: %v", ErrTransactionEditorInstructionIndexOutOfRange, index)
+	}
+	output := append([]Instruction{}, e.Instructions...)
+	output[index] = instruction
+	e.Instructions = output
+	return nil
+}
+
+// InvalidateSignatures drops existing signatures, the recompiled transaction is unsigned
+func (e *TransactionEditor) InvalidateSignatures() {
+	e.invalidated = true
+}
+
+// Transaction recompiles the transaction. signatures are kept only if the message
+// doesn't change, otherwise every slot is zeroed once signatures are invalidated.
+func (e *TransactionEditor) Transaction() (Transaction, error) {
+	if !e.invalidated && reflect.DeepEqual(e.NewMessageParam, e.originalParam) {
+		return e.original, nil
+	}
+
+	message, err := CompileMessage(e.NewMessageParam)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if e.invalidated {
+		return NewUnsignedTransaction(message), nil
+	}
+
+	data, err := message.Serialize()
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	originalData, err := e.original.Message.Serialize()
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	if bytes.Equal(data, originalData) {
+		return Transaction{Signatures: e.original.Signatures, Message: message}, nil
+	}
+	for _, sig := range e.original.Signatures {
+		if !sig.IsZero() {
+			return Transaction{}, ErrTransactionEditorSignedTransactionModified
+		}
+	}
+	return NewUnsignedTransaction(message), nil
+}
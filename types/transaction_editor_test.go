@@ -0,0 +1,146 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionEditor(t *testing.T) {
+	feePayer := NewAccount()
+	programID := common.PublicKeyFromString("CustomProgram111111111111111111111111111111")
+	memoProgramID := common.PublicKeyFromString("MemoSq4gqABAXKb96qnH8TuNZBSfgdwCPL8FoqZuaqv")
+	table := AddressLookupTableAccount{Key: testPublicKey(20000), Addresses: []common.PublicKey{testPublicKey(0), testPublicKey(1)}}
+	instruction := Instruction{
+		ProgramID: programID,
+		Accounts: []AccountMeta{
+			{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+			{PubKey: testPublicKey(0), IsSigner: false, IsWritable: true},
+			{PubKey: testPublicKey(1), IsSigner: false, IsWritable: false},
+		},
+		Data: []byte{1},
+	}
+	memo := Instruction{ProgramID: memoProgramID, Accounts: []AccountMeta{}, Data: []byte("memo")}
+	param := NewMessageParam{
+		FeePayer:                   feePayer.PublicKey,
+		Instructions:               []Instruction{instruction},
+		RecentBlockhash:            "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		AddressLookupTableAccounts: []AddressLookupTableAccount{table},
+	}
+	tx, err := NewTransaction(NewTransactionParam{Message: NewMessage(param), Signers: []Account{feePayer}})
+	require.NoError(t, err)
+
+	t.Run("decompile round trip", func(t *testing.T) {
+		got, err := tx.Message.Decompile([]AddressLookupTableAccount{{Key: testPublicKey(20001)}, table})
+		require.NoError(t, err)
+		want := param
+		want.Version = MessageVersionV0
+		assert.Equal(t, want, got)
+		assert.Equal(t, tx.Message, NewMessage(got))
+
+		_, err = tx.Message.Decompile(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("unchanged keeps signatures", func(t *testing.T) {
+		editor, err := NewTransactionEditor(tx, []AddressLookupTableAccount{table})
+		require.NoError(t, err)
+		got, err := editor.Transaction()
+		require.NoError(t, err)
+		assert.Equal(t, tx, got)
+	})
+
+	t.Run("modified signed tx needs invalidation", func(t *testing.T) {
+		editor, err := NewTransactionEditor(tx, []AddressLookupTableAccount{table})
+		require.NoError(t, err)
+		require.NoError(t, editor.InsertInstructions(0, memo))
+		_, err = editor.Transaction()
+		assert.ErrorIs(t, err, ErrTransactionEditorSignedTransactionModified)
+
+		editor.InvalidateSignatures()
+		got, err := editor.Transaction()
+		require.NoError(t, err)
+		assert.Equal(t, []common.PublicKey{feePayer.PublicKey}, got.MissingSigners())
+		instructions, err := got.Message.DecompileInstructionsWithAddressLookupTables([]AddressLookupTableAccount{table})
+		require.NoError(t, err)
+		assert.Equal(t, []Instruction{memo, instruction}, instructions)
+		assert.Equal(t, MessageVersion(MessageVersionV0), got.Message.Version)
+
+		// the original tx is untouched
+		assert.Empty(t, tx.MissingSigners())
+	})
+
+	t.Run("insert remove replace", func(t *testing.T) {
+		editor, err := NewTransactionEditor(NewUnsignedTransaction(tx.Message), []AddressLookupTableAccount{table})
+		require.NoError(t, err)
+		require.NoError(t, editor.InsertInstructions(1, memo, memo))
+		replaced := Instruction{ProgramID: memoProgramID, Accounts: []AccountMeta{}, Data: []byte("replaced")}
+		require.NoError(t, editor.ReplaceInstruction(2, replaced))
+		require.NoError(t, editor.RemoveInstruction(0))
+		assert.Equal(t, []Instruction{memo, replaced}, editor.Instructions)
+
+		assert.ErrorIs(t, editor.InsertInstructions(3, memo), ErrTransactionEditorInstructionIndexOutOfRange)
+		assert.ErrorIs(t, editor.RemoveInstruction(2), ErrTransactionEditorInstructionIndexOutOfRange)
+		assert.ErrorIs(t, editor.ReplaceInstruction(-1, memo), ErrTransactionEditorInstructionIndexOutOfRange)
+
+		// an unsigned tx doesn't need invalidation, unused tables are dropped
+		got, err := editor.Transaction()
+		require.NoError(t, err)
+		assert.Equal(t, NewUnsignedTransaction(NewMessage(NewMessageParam{
+			FeePayer:                   feePayer.PublicKey,
+			Instructions:               []Instruction{memo, replaced},
+			RecentBlockhash:            "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			AddressLookupTableAccounts: []AddressLookupTableAccount{table},
+		})), got)
+		assert.Empty(t, got.Message.AddressLookupTables)
+	})
+
+	t.Run("v0 without lookups stays v0", func(t *testing.T) {
+		v0 := NewUnsignedTransaction(NewMessage(NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			Instructions:    []Instruction{memo},
+			RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			Version:         MessageVersionV0,
+		}))
+		require.Equal(t, MessageVersion(MessageVersionV0), v0.Message.Version)
+		require.Empty(t, v0.Message.AddressLookupTables)
+
+		editor, err := NewTransactionEditor(v0, nil)
+		require.NoError(t, err)
+		require.NoError(t, editor.InsertInstructions(0, memo))
+		got, err := editor.Transaction()
+		require.NoError(t, err)
+		assert.Equal(t, MessageVersion(MessageVersionV0), got.Message.Version)
+		assert.Equal(t, []Instruction{memo, memo}, got.Message.DecompileInstructions())
+
+		// and it still serializes as a versioned message
+		data, err := got.Message.Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, byte(0x80), data[0])
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		legacy, err := NewTransaction(NewTransactionParam{
+			Message: NewMessage(NewMessageParam{
+				FeePayer:        feePayer.PublicKey,
+				Instructions:    []Instruction{instruction},
+				RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			}),
+			Signers: []Account{feePayer},
+		})
+		require.NoError(t, err)
+		editor, err := NewTransactionEditor(legacy, nil)
+		require.NoError(t, err)
+		require.NoError(t, editor.InsertInstructions(len(editor.Instructions), memo))
+		editor.InvalidateSignatures()
+		got, err := editor.Transaction()
+		require.NoError(t, err)
+		require.NoError(t, got.Sign(context.Background(), feePayer))
+		assert.Equal(t, MessageVersion(MessageVersionLegacy), got.Message.Version)
+		assert.Equal(t, []Instruction{instruction, memo}, got.Message.DecompileInstructions())
+		assert.NoError(t, got.VerifySignatures())
+	})
+}
@@ -0,0 +1,378 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type TransactionErrorType string
+
+const (
+	TransactionErrorAccountInUse                          TransactionErrorType = "AccountInUse"
+	TransactionErrorAccountLoadedTwice                    TransactionErrorType = "AccountLoadedTwice"
+	TransactionErrorAccountNotFound                       TransactionErrorType = "AccountNotFound"
+	TransactionErrorProgramAccountNotFound                TransactionErrorType = "ProgramAccountNotFound"
+	TransactionErrorInsufficientFundsForFee               TransactionErrorType = "InsufficientFundsForFee"
+	TransactionErrorInvalidAccountForFee                  TransactionErrorType = "InvalidAccountForFee"
+	TransactionErrorAlreadyProcessed                      TransactionErrorType = "AlreadyProcessed"
+	TransactionErrorBlockhashNotFound                     TransactionErrorType = "BlockhashNotFound"
+	TransactionErrorInstructionError                      TransactionErrorType = "InstructionError"
+	TransactionErrorCallChainTooDeep                      TransactionErrorType = "CallChainTooDeep"
+	TransactionErrorMissingSignatureForFee                TransactionErrorType = "MissingSignatureForFee"
+	TransactionErrorInvalidAccountIndex                   TransactionErrorType = "InvalidAccountIndex"
+	TransactionErrorSignatureFailure                      TransactionErrorType = "SignatureFailure"
+	TransactionErrorInvalidProgramForExecution            TransactionErrorType = "InvalidProgramForExecution"
+	TransactionErrorSanitizeFailure                       TransactionErrorType = "SanitizeFailure"
+	TransactionErrorClusterMaintenance                    TransactionErrorType = "ClusterMaintenance"
+	TransactionErrorAccountBorrowOutstanding              TransactionErrorType = "AccountBorrowOutstanding"
+	TransactionErrorWouldExceedMaxBlockCostLimit          TransactionErrorType = "WouldExceedMaxBlockCostLimit"
+	TransactionErrorUnsupportedVersion                    TransactionErrorType = "UnsupportedVersion"
+	TransactionErrorInvalidWritableAccount                TransactionErrorType = "InvalidWritableAccount"
+	TransactionErrorWouldExceedMaxAccountCostLimit        TransactionErrorType = "WouldExceedMaxAccountCostLimit"
+	TransactionErrorWouldExceedAccountDataBlockLimit      TransactionErrorType = "WouldExceedAccountDataBlockLimit"
+	TransactionErrorTooManyAccountLocks                   TransactionErrorType = "TooManyAccountLocks"
+	TransactionErrorAddressLookupTableNotFound            TransactionErrorType = "AddressLookupTableNotFound"
+	TransactionErrorInvalidAddressLookupTableOwner        TransactionErrorType = "InvalidAddressLookupTableOwner"
+	TransactionErrorInvalidAddressLookupTableData         TransactionErrorType = "InvalidAddressLookupTableData"
+	TransactionErrorInvalidAddressLookupTableIndex        TransactionErrorType = "InvalidAddressLookupTableIndex"
+	TransactionErrorInvalidRentPayingAccount              TransactionErrorType = "InvalidRentPayingAccount"
+	TransactionErrorWouldExceedMaxVoteCostLimit           TransactionErrorType = "WouldExceedMaxVoteCostLimit"
+	TransactionErrorWouldExceedAccountDataTotalLimit      TransactionErrorType = "WouldExceedAccountDataTotalLimit"
+	TransactionErrorDuplicateInstruction                  TransactionErrorType = "DuplicateInstruction"
+	TransactionErrorInsufficientFundsForRent              TransactionErrorType = "InsufficientFundsForRent"
+	TransactionErrorMaxLoadedAccountsDataSizeExceeded     TransactionErrorType = "MaxLoadedAccountsDataSizeExceeded"
+	TransactionErrorInvalidLoadedAccountsDataSizeLimit    TransactionErrorType = "InvalidLoadedAccountsDataSizeLimit"
+	TransactionErrorResanitizationNeeded                  TransactionErrorType = "ResanitizationNeeded"
+	TransactionErrorProgramExecutionTemporarilyRestricted TransactionErrorType = "ProgramExecutionTemporarilyRestricted"
+	TransactionErrorUnbalancedTransaction                 TransactionErrorType = "UnbalancedTransaction"
+	TransactionErrorProgramCacheHitMaxLimit               TransactionErrorType = "ProgramCacheHitMaxLimit"
+)
+
+var transactionErrorMessages = map[TransactionErrorType]string{
+	TransactionErrorAccountInUse:                       "Account in use",
+	TransactionErrorAccountLoadedTwice:                 "Account loaded twice",
+	TransactionErrorAccountNotFound:                    "Attempt to debit an account but found no record of a prior credit.",
+	TransactionErrorProgramAccountNotFound:             "Attempt to load a program that does not exist",
+	TransactionErrorInsufficientFundsForFee:            "Insufficient funds for fee",
+	TransactionErrorInvalidAccountForFee:               "This account may not be used to pay transaction fees",
+	TransactionErrorAlreadyProcessed:                   "This transaction has already been processed",
+	TransactionErrorBlockhashNotFound:                  "Blockhash not found",
+	TransactionErrorCallChainTooDeep:                   "Loader call chain is too deep",
+	TransactionErrorMissingSignatureForFee:             "Transaction requires a fee but has no signature present",
+	TransactionErrorInvalidAccountIndex:                "Transaction contains an invalid account reference",
+	TransactionErrorSignatureFailure:                   "Transaction did not pass signature verification",
+	TransactionErrorInvalidProgramForExecution:         "This program may not be used for executing instructions",
+	TransactionErrorSanitizeFailure:                    "Transaction failed to sanitize accounts offsets correctly",
+	TransactionErrorClusterMaintenance:                 "Transactions are currently disabled due to cluster maintenance",
+	TransactionErrorAccountBorrowOutstanding:           "Transaction processing left an account with an outstanding borrowed reference",
+	TransactionErrorWouldExceedMaxBlockCostLimit:       "Transaction would exceed max Block Cost Limit",
+	TransactionErrorUnsupportedVersion:                 "Transaction version is unsupported",
+	TransactionErrorInvalidWritableAccount:             "Transaction loads a writable account that cannot be written",
+	TransactionErrorWouldExceedMaxAccountCostLimit:     "Transaction would exceed max account limit within the block",
+	TransactionErrorWouldExceedAccountDataBlockLimit:   "Transaction would exceed account data limit within the block",
+	TransactionErrorTooManyAccountLocks:                "Transaction locked too many accounts",
+	TransactionErrorAddressLookupTableNotFound:         "Transaction loads an address table account that doesn't exist",
+	TransactionErrorInvalidAddressLookupTableOwner:     "Transaction loads an address table account with an invalid owner",
+	TransactionErrorInvalidAddressLookupTableData:      "Transaction loads an address table account with invalid data",
+	TransactionErrorInvalidAddressLookupTableIndex:     "Transaction address table lookup uses an invalid index",
+	TransactionErrorInvalidRentPayingAccount:           "Transaction leaves an account with a lower balance than rent-exempt minimum",
+	TransactionErrorWouldExceedMaxVoteCostLimit:        "Transaction would exceed max Vote Cost Limit",
+	TransactionErrorWouldExceedAccountDataTotalLimit:   "Transaction would exceed total account data limit",
+	TransactionErrorMaxLoadedAccountsDataSizeExceeded:  "Transaction exceeded max loaded accounts data size cap",
+	TransactionErrorInvalidLoadedAccountsDataSizeLimit: "LoadedAccountsDataSizeLimit set for transaction must be greater than 0.",
+	TransactionErrorResanitizationNeeded:               "ResanitizationNeeded",
+	TransactionErrorUnbalancedTransaction:              "Sum of account balances before and after transaction do not match",
+	TransactionErrorProgramCacheHitMaxLimit:            "Program cache hit max limit",
+}
+
+// TransactionError is the reason a transaction failed, it is the `err` field of
+// transaction meta, simulation results and signature statuses.
+type TransactionError struct {
+	Type TransactionErrorType
+	// InstructionIndex is set for InstructionError and DuplicateInstruction
+	InstructionIndex uint8
+	// InstructionError is set for InstructionError
+	InstructionError *InstructionError
+	// AccountIndex is set for InsufficientFundsForRent and ProgramExecutionTemporarilyRestricted
+	AccountIndex uint8
+}
+
+// ParseTransactionError converts a decoded json value, e.g. `"AccountInUse"` or
+// `{"InstructionError":[1,{"Custom":6001}]}`, into a TransactionError. it returns
+// nil if the value is nil.
+func ParseTransactionError(v any) (*TransactionError, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transaction error, err: %v", err)
+	}
+	var txErr TransactionError
+	if err := json.Unmarshal(b, &txErr); err != nil {
+		return nil, err
+	}
+	return &txErr, nil
+}
+
+func (e *TransactionError) Error() string {
+	switch e.Type {
+	case TransactionErrorInstructionError:
+		return fmt.Sprintf("Error processing Instruction %d: %v", e.InstructionIndex, e.InstructionError)
+	case TransactionErrorDuplicateInstruction:
+		return fmt.Sprintf("Transaction contains a duplicate instruction (%d) that is not allowed", e.InstructionIndex)
+	case TransactionErrorInsufficientFundsForRent:
+		return fmt.Sprintf("Transaction results in an account (%d) with insufficient funds for rent", e.AccountIndex)
+	case TransactionErrorProgramExecutionTemporarilyRestricted:
+		return fmt.Sprintf("Execution of the program referenced by account at index %d is temporarily restricted.", e.AccountIndex)
+	}
+	if msg, ok := transactionErrorMessages[e.Type]; ok {
+		return msg
+	}
+	return string(e.Type)
+}
+
+func (e *TransactionError) Unwrap() error {
+	if e.InstructionError == nil {
+		return nil
+	}
+	return e.InstructionError
+}
+
+func (e *TransactionError) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*e = TransactionError{Type: TransactionErrorType(name)}
+		return nil
+	}
+
+	var variant map[string]json.RawMessage
+	if err := json.Unmarshal(b, &variant); err != nil || len(variant) != 1 {
+		return fmt.Errorf("unexpected transaction error: %s", string(b))
+	}
+	for k, v := range variant {
+		*e = TransactionError{Type: TransactionErrorType(k)}
+		var err error
+		switch e.Type {
+		case TransactionErrorInstructionError:
+			var fields []json.RawMessage
+			if err = json.Unmarshal(v, &fields); err != nil || len(fields) != 2 {
+				return fmt.Errorf("unexpected instruction error: %s", string(v))
+			}
+			e.InstructionError = &InstructionError{}
+			if err = json.Unmarshal(fields[0], &e.InstructionIndex); err == nil {
+				err = json.Unmarshal(fields[1], e.InstructionError)
+			}
+		case TransactionErrorDuplicateInstruction:
+			err = json.Unmarshal(v, &e.InstructionIndex)
+		case TransactionErrorInsufficientFundsForRent, TransactionErrorProgramExecutionTemporarilyRestricted:
+			var fields struct {
+				AccountIndex uint8 `json:"account_index"`
+			}
+			err = json.Unmarshal(v, &fields)
+			e.AccountIndex = fields.AccountIndex
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse %v, err: %v", k, err)
+		}
+	}
+	return nil
+}
+
+func (e TransactionError) MarshalJSON() ([]byte, error) {
+	switch e.Type {
+	case TransactionErrorInstructionError:
+		return json.Marshal(map[string]any{string(e.Type): []any{e.InstructionIndex, e.InstructionError}})
+	case TransactionErrorDuplicateInstruction:
+		return json.Marshal(map[string]any{string(e.Type): e.InstructionIndex})
+	case TransactionErrorInsufficientFundsForRent, TransactionErrorProgramExecutionTemporarilyRestricted:
+		return json.Marshal(map[string]any{string(e.Type): map[string]any{"account_index": e.AccountIndex}})
+	}
+	return json.Marshal(string(e.Type))
+}
+
+type InstructionErrorType string
+
+const (
+	InstructionErrorGenericError                           InstructionErrorType = "GenericError"
+	InstructionErrorInvalidArgument                        InstructionErrorType = "InvalidArgument"
+	InstructionErrorInvalidInstructionData                 InstructionErrorType = "InvalidInstructionData"
+	InstructionErrorInvalidAccountData                     InstructionErrorType = "InvalidAccountData"
+	InstructionErrorAccountDataTooSmall                    InstructionErrorType = "AccountDataTooSmall"
+	InstructionErrorInsufficientFunds                      InstructionErrorType = "InsufficientFunds"
+	InstructionErrorIncorrectProgramId                     InstructionErrorType = "IncorrectProgramId"
+	InstructionErrorMissingRequiredSignature               InstructionErrorType = "MissingRequiredSignature"
+	InstructionErrorAccountAlreadyInitialized              InstructionErrorType = "AccountAlreadyInitialized"
+	InstructionErrorUninitializedAccount                   InstructionErrorType = "UninitializedAccount"
+	InstructionErrorUnbalancedInstruction                  InstructionErrorType = "UnbalancedInstruction"
+	InstructionErrorModifiedProgramId                      InstructionErrorType = "ModifiedProgramId"
+	InstructionErrorExternalAccountLamportSpend            InstructionErrorType = "ExternalAccountLamportSpend"
+	InstructionErrorExternalAccountDataModified            InstructionErrorType = "ExternalAccountDataModified"
+	InstructionErrorReadonlyLamportChange                  InstructionErrorType = "ReadonlyLamportChange"
+	InstructionErrorReadonlyDataModified                   InstructionErrorType = "ReadonlyDataModified"
+	InstructionErrorDuplicateAccountIndex                  InstructionErrorType = "DuplicateAccountIndex"
+	InstructionErrorExecutableModified                     InstructionErrorType = "ExecutableModified"
+	InstructionErrorRentEpochModified                      InstructionErrorType = "RentEpochModified"
+	InstructionErrorNotEnoughAccountKeys                   InstructionErrorType = "NotEnoughAccountKeys"
+	InstructionErrorAccountDataSizeChanged                 InstructionErrorType = "AccountDataSizeChanged"
+	InstructionErrorAccountNotExecutable                   InstructionErrorType = "AccountNotExecutable"
+	InstructionErrorAccountBorrowFailed                    InstructionErrorType = "AccountBorrowFailed"
+	InstructionErrorAccountBorrowOutstanding               InstructionErrorType = "AccountBorrowOutstanding"
+	InstructionErrorDuplicateAccountOutOfSync              InstructionErrorType = "DuplicateAccountOutOfSync"
+	InstructionErrorCustom                                 InstructionErrorType = "Custom"
+	InstructionErrorInvalidError                           InstructionErrorType = "InvalidError"
+	InstructionErrorExecutableDataModified                 InstructionErrorType = "ExecutableDataModified"
+	InstructionErrorExecutableLamportChange                InstructionErrorType = "ExecutableLamportChange"
+	InstructionErrorExecutableAccountNotRentExempt         InstructionErrorType = "ExecutableAccountNotRentExempt"
+	InstructionErrorUnsupportedProgramId                   InstructionErrorType = "UnsupportedProgramId"
+	InstructionErrorCallDepth                              InstructionErrorType = "CallDepth"
+	InstructionErrorMissingAccount                         InstructionErrorType = "MissingAccount"
+	InstructionErrorReentrancyNotAllowed                   InstructionErrorType = "ReentrancyNotAllowed"
+	InstructionErrorMaxSeedLengthExceeded                  InstructionErrorType = "MaxSeedLengthExceeded"
+	InstructionErrorInvalidSeeds                           InstructionErrorType = "InvalidSeeds"
+	InstructionErrorInvalidRealloc                         InstructionErrorType = "InvalidRealloc"
+	InstructionErrorComputationalBudgetExceeded            InstructionErrorType = "ComputationalBudgetExceeded"
+	InstructionErrorPrivilegeEscalation                    InstructionErrorType = "PrivilegeEscalation"
+	InstructionErrorProgramEnvironmentSetupFailure         InstructionErrorType = "ProgramEnvironmentSetupFailure"
+	InstructionErrorProgramFailedToComplete                InstructionErrorType = "ProgramFailedToComplete"
+	InstructionErrorProgramFailedToCompile                 InstructionErrorType = "ProgramFailedToCompile"
+	InstructionErrorImmutable                              InstructionErrorType = "Immutable"
+	InstructionErrorIncorrectAuthority                     InstructionErrorType = "IncorrectAuthority"
+	InstructionErrorBorshIoError                           InstructionErrorType = "BorshIoError"
+	InstructionErrorAccountNotRentExempt                   InstructionErrorType = "AccountNotRentExempt"
+	InstructionErrorInvalidAccountOwner                    InstructionErrorType = "InvalidAccountOwner"
+	InstructionErrorArithmeticOverflow                     InstructionErrorType = "ArithmeticOverflow"
+	InstructionErrorUnsupportedSysvar                      InstructionErrorType = "UnsupportedSysvar"
+	InstructionErrorIllegalOwner                           InstructionErrorType = "IllegalOwner"
+	InstructionErrorMaxAccountsDataAllocationsExceeded     InstructionErrorType = "MaxAccountsDataAllocationsExceeded"
+	InstructionErrorMaxAccountsExceeded                    InstructionErrorType = "MaxAccountsExceeded"
+	InstructionErrorMaxInstructionTraceLengthExceeded      InstructionErrorType = "MaxInstructionTraceLengthExceeded"
+	InstructionErrorBuiltinProgramsMustConsumeComputeUnits InstructionErrorType = "BuiltinProgramsMustConsumeComputeUnits"
+)
+
+var instructionErrorMessages = map[InstructionErrorType]string{
+	InstructionErrorGenericError:                           "generic instruction error",
+	InstructionErrorInvalidArgument:                        "invalid program argument",
+	InstructionErrorInvalidInstructionData:                 "invalid instruction data",
+	InstructionErrorInvalidAccountData:                     "invalid account data for instruction",
+	InstructionErrorAccountDataTooSmall:                    "account data too small for instruction",
+	InstructionErrorInsufficientFunds:                      "insufficient funds for instruction",
+	InstructionErrorIncorrectProgramId:                     "incorrect program id for instruction",
+	InstructionErrorMissingRequiredSignature:               "missing required signature for instruction",
+	InstructionErrorAccountAlreadyInitialized:              "instruction requires an uninitialized account",
+	InstructionErrorUninitializedAccount:                   "instruction requires an initialized account",
+	InstructionErrorUnbalancedInstruction:                  "sum of account balances before and after instruction do not match",
+	InstructionErrorModifiedProgramId:                      "instruction illegally modified the program id of an account",
+	InstructionErrorExternalAccountLamportSpend:            "instruction spent from the balance of an account it does not own",
+	InstructionErrorExternalAccountDataModified:            "instruction modified data of an account it does not own",
+	InstructionErrorReadonlyLamportChange:                  "instruction changed the balance of a read-only account",
+	InstructionErrorReadonlyDataModified:                   "instruction modified data of a read-only account",
+	InstructionErrorDuplicateAccountIndex:                  "instruction contains duplicate accounts",
+	InstructionErrorExecutableModified:                     "instruction changed executable bit of an account",
+	InstructionErrorRentEpochModified:                      "instruction modified rent epoch of an account",
+	InstructionErrorNotEnoughAccountKeys:                   "insufficient account keys for instruction",
+	InstructionErrorAccountDataSizeChanged:                 "program other than the account's owner changed the size of the account data",
+	InstructionErrorAccountNotExecutable:                   "instruction expected an executable account",
+	InstructionErrorAccountBorrowFailed:                    "instruction tries to borrow reference for an account which is already borrowed",
+	InstructionErrorAccountBorrowOutstanding:               "instruction left account with an outstanding borrowed reference",
+	InstructionErrorDuplicateAccountOutOfSync:              "instruction modifications of multiply-passed account differ",
+	InstructionErrorInvalidError:                           "program returned invalid error code",
+	InstructionErrorExecutableDataModified:                 "instruction changed executable accounts data",
+	InstructionErrorExecutableLamportChange:                "instruction changed the balance of an executable account",
+	InstructionErrorExecutableAccountNotRentExempt:         "executable accounts must be rent exempt",
+	InstructionErrorUnsupportedProgramId:                   "Unsupported program id",
+	InstructionErrorCallDepth:                              "Cross-program invocation call depth too deep",
+	InstructionErrorMissingAccount:                         "An account required by the instruction is missing",
+	InstructionErrorReentrancyNotAllowed:                   "Cross-program invocation reentrancy not allowed for this instruction",
+	InstructionErrorMaxSeedLengthExceeded:                  "Length of the seed is too long for address generation",
+	InstructionErrorInvalidSeeds:                           "Provided seeds do not result in a valid address",
+	InstructionErrorInvalidRealloc:                         "Failed to reallocate account data",
+	InstructionErrorComputationalBudgetExceeded:            "Computational budget exceeded",
+	InstructionErrorPrivilegeEscalation:                    "Cross-program invocation with unauthorized signer or writable account",
+	InstructionErrorProgramEnvironmentSetupFailure:         "Failed to create program execution environment",
+	InstructionErrorProgramFailedToComplete:                "Program failed to complete",
+	InstructionErrorProgramFailedToCompile:                 "Program failed to compile",
+	InstructionErrorImmutable:                              "Account is immutable",
+	InstructionErrorIncorrectAuthority:                     "Incorrect authority provided",
+	InstructionErrorAccountNotRentExempt:                   "An account does not have enough lamports to be rent-exempt",
+	InstructionErrorInvalidAccountOwner:                    "Invalid account owner",
+	InstructionErrorArithmeticOverflow:                     "Program arithmetic overflowed",
+	InstructionErrorUnsupportedSysvar:                      "Unsupported sysvar",
+	InstructionErrorIllegalOwner:                           "Provided owner is not allowed",
+	InstructionErrorMaxAccountsDataAllocationsExceeded:     "Accounts data allocations exceeded the maximum allowed per transaction",
+	InstructionErrorMaxAccountsExceeded:                    "Max accounts exceeded",
+	InstructionErrorMaxInstructionTraceLengthExceeded:      "Max instruction trace length exceeded",
+	InstructionErrorBuiltinProgramsMustConsumeComputeUnits: "Builtin programs must consume compute units",
+}
+
+// InstructionError is the reason an instruction failed
+type InstructionError struct {
+	Type InstructionErrorType
+	// Custom is the program defined error code, set for Custom
+	Custom uint32
+	// BorshIoError is the message of BorshIoError
+	BorshIoError string
+	// ProgramError is the named error of the custom code, it is set by
+	// ProgramErrorCatalog.Resolve if the program is known
+	ProgramError *ProgramError
+}
+
+func (e *InstructionError) Error() string {
+	switch e.Type {
+	case InstructionErrorCustom:
+		if e.ProgramError != nil {
+			return e.ProgramError.Error()
+		}
+		return fmt.Sprintf("custom program error: %#x", e.Custom)
+	case InstructionErrorBorshIoError:
+		return fmt.Sprintf("Failed to serialize or deserialize account data: %v", e.BorshIoError)
+	}
+	if msg, ok := instructionErrorMessages[e.Type]; ok {
+		return msg
+	}
+	return string(e.Type)
+}
+
+func (e *InstructionError) Unwrap() error {
+	if e.ProgramError == nil {
+		return nil
+	}
+	return e.ProgramError
+}
+
+func (e *InstructionError) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*e = InstructionError{Type: InstructionErrorType(name)}
+		return nil
+	}
+
+	var variant map[string]json.RawMessage
+	if err := json.Unmarshal(b, &variant); err != nil || len(variant) != 1 {
+		return fmt.Errorf("unexpected instruction error: %s", string(b))
+	}
+	for k, v := range variant {
+		*e = InstructionError{Type: InstructionErrorType(k)}
+		var err error
+		switch e.Type {
+		case InstructionErrorCustom:
+			err = json.Unmarshal(v, &e.Custom)
+		case InstructionErrorBorshIoError:
+			err = json.Unmarshal(v, &e.BorshIoError)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse %v, err: %v", k, err)
+		}
+	}
+	return nil
+}
+
+func (e InstructionError) MarshalJSON() ([]byte, error) {
+	switch e.Type {
+	case InstructionErrorCustom:
+		return json.Marshal(map[string]any{string(e.Type): e.Custom})
+	case InstructionErrorBorshIoError:
+		return json.Marshal(map[string]any{string(e.Type): e.BorshIoError})
+	}
+	return json.Marshal(string(e.Type))
+}
@@ -0,0 +1,166 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransactionError(t *testing.T) {
+	type args struct {
+		raw string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        *TransactionError
+		wantMessage string
+	}{
+		{
+			name:        "unit variant",
+			args:        args{raw: `"AccountInUse"`},
+			want:        &TransactionError{Type: TransactionErrorAccountInUse},
+			wantMessage: "Account in use",
+		},
+		{
+			name: "instruction error with custom code",
+			args: args{raw: `{"InstructionError":[1,{"Custom":6001}]}`},
+			want: &TransactionError{
+				Type:             TransactionErrorInstructionError,
+				InstructionIndex: 1,
+				InstructionError: &InstructionError{Type: InstructionErrorCustom, Custom: 6001},
+			},
+			wantMessage: "Error processing Instruction 1: custom program error: 0x1771",
+		},
+		{
+			name: "instruction error",
+			args: args{raw: `{"InstructionError":[0,"InvalidAccountData"]}`},
+			want: &TransactionError{
+				Type:             TransactionErrorInstructionError,
+				InstructionError: &InstructionError{Type: InstructionErrorInvalidAccountData},
+			},
+			wantMessage: "Error processing Instruction 0: invalid account data for instruction",
+		},
+		{
+			name: "instruction error with borsh io error",
+			args: args{raw: `{"InstructionError":[2,{"BorshIoError":"Unexpected length of input"}]}`},
+			want: &TransactionError{
+				Type:             TransactionErrorInstructionError,
+				InstructionIndex: 2,
+				InstructionError: &InstructionError{Type: InstructionErrorBorshIoError, BorshIoError: "Unexpected length of input"},
+			},
+			wantMessage: "Error processing Instruction 2: Failed to serialize or deserialize account data: Unexpected length of input",
+		},
+		{
+			name:        "duplicate instruction",
+			args:        args{raw: `{"DuplicateInstruction":3}`},
+			want:        &TransactionError{Type: TransactionErrorDuplicateInstruction, InstructionIndex: 3},
+			wantMessage: "Transaction contains a duplicate instruction (3) that is not allowed",
+		},
+		{
+			name:        "insufficient funds for rent",
+			args:        args{raw: `{"InsufficientFundsForRent":{"account_index":2}}`},
+			want:        &TransactionError{Type: TransactionErrorInsufficientFundsForRent, AccountIndex: 2},
+			wantMessage: "Transaction results in an account (2) with insufficient funds for rent",
+		},
+		{
+			name:        "program execution temporarily restricted",
+			args:        args{raw: `{"ProgramExecutionTemporarilyRestricted":{"account_index":4}}`},
+			want:        &TransactionError{Type: TransactionErrorProgramExecutionTemporarilyRestricted, AccountIndex: 4},
+			wantMessage: "Execution of the program referenced by account at index 4 is temporarily restricted.",
+		},
+		{
+			name:        "unknown variant",
+			args:        args{raw: `"SomethingNew"`},
+			want:        &TransactionError{Type: "SomethingNew"},
+			wantMessage: "SomethingNew",
+		},
+		{
+			name: "nil",
+			args: args{raw: `null`},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			require.NoError(t, json.Unmarshal([]byte(tt.args.raw), &v))
+			got, err := ParseTransactionError(v)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if got == nil {
+				return
+			}
+			assert.Equal(t, tt.wantMessage, got.Error())
+
+			b, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.args.raw, string(b))
+		})
+	}
+}
+
+func TestParseTransactionError_Invalid(t *testing.T) {
+	for _, raw := range []any{
+		1,
+		map[string]any{"InstructionError": 1},
+		map[string]any{"InstructionError": []any{0}},
+		map[string]any{"InstructionError": []any{0, map[string]any{"Custom": "x"}}},
+		map[string]any{"AccountInUse": 1, "AccountLoadedTwice": 1},
+	} {
+		_, err := ParseTransactionError(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestProgramErrorCatalog_Resolve(t *testing.T) {
+	programId := common.PublicKeyFromString("35HSbe2xiLfid5QJeETGnUsGhkAiJWRKPrEGdQQ5xXrP")
+	catalog := NewProgramErrorCatalog()
+	catalog.Register(programId, []ProgramError{
+		{Program: "test", Code: 1, Name: "InsufficientFunds", Message: "insufficient funds"},
+	})
+
+	message := Message{
+		Accounts: []common.PublicKey{common.PublicKeyFromString("FUarP2p5EnxD66vVDL4PWRoWMzA56ZVHG24hpEDFShEz"), programId},
+		Instructions: []CompiledInstruction{
+			{ProgramIDIndex: 1},
+		},
+	}
+
+	txErr := &TransactionError{
+		Type:             TransactionErrorInstructionError,
+		InstructionError: &InstructionError{Type: InstructionErrorCustom, Custom: 1},
+	}
+	catalog.Resolve(txErr, message)
+	assert.Equal(t, "Error processing Instruction 0: test: insufficient funds", txErr.Error())
+
+	var programErr *ProgramError
+	require.True(t, errors.As(txErr, &programErr))
+	assert.Equal(t, "InsufficientFunds", programErr.Name)
+
+	// unknown code
+	txErr = &TransactionError{
+		Type:             TransactionErrorInstructionError,
+		InstructionError: &InstructionError{Type: InstructionErrorCustom, Custom: 2},
+	}
+	catalog.Resolve(txErr, message)
+	assert.Nil(t, txErr.InstructionError.ProgramError)
+
+	// instruction index out of range
+	txErr = &TransactionError{
+		Type:             TransactionErrorInstructionError,
+		InstructionIndex: 1,
+		InstructionError: &InstructionError{Type: InstructionErrorCustom, Custom: 1},
+	}
+	catalog.Resolve(txErr, message)
+	assert.Nil(t, txErr.InstructionError.ProgramError)
+
+	// nil catalog and nil error
+	var nilCatalog *ProgramErrorCatalog
+	nilCatalog.Resolve(txErr, message)
+	catalog.Resolve(nil, message)
+}
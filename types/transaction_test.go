@@ -495,3 +495,73 @@ func TestTransaction_AddSignature(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_PartialSigning(t *testing.T) {
+	feePayer := NewAccount()
+	custodian1 := NewAccount()
+	custodian2 := NewAccount()
+	outsider := NewAccount()
+
+	msg := NewMessage(NewMessageParam{
+		FeePayer: feePayer.PublicKey,
+		Instructions: []Instruction{
+			{
+				ProgramID: common.PublicKeyFromString("CustomProgram111111111111111111111111111111"),
+				Accounts: []AccountMeta{
+					{PubKey: custodian1.PublicKey, IsSigner: true, IsWritable: true},
+					{PubKey: custodian2.PublicKey, IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{},
+			},
+		},
+		RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	serMsg, err := msg.Serialize()
+	assert.NoError(t, err)
+
+	tx := NewUnsignedTransaction(msg)
+	required := []common.PublicKey{feePayer.PublicKey, custodian1.PublicKey, custodian2.PublicKey}
+	assert.Equal(t, required, tx.RequiredSigners())
+	assert.Equal(t, required, tx.MissingSigners())
+	assert.NoError(t, tx.VerifySignatures())
+
+	// the first party signs and hands it off
+	assert.NoError(t, tx.AddSignatureForPubkey(custodian1.PublicKey, custodian1.Sign(serMsg)))
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey, custodian2.PublicKey}, tx.MissingSigners())
+	raw, err := tx.SerializePartiallySigned()
+	assert.NoError(t, err)
+
+	// the second party picks it up
+	handOff, err := TransactionDeserialize(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, tx, handOff)
+	assert.ErrorIs(t, handOff.AddSignatureForPubkey(custodian2.PublicKey, custodian1.Sign(serMsg)), ErrTransactionInvalidSignature)
+	assert.ErrorIs(t, handOff.AddSignatureForPubkey(outsider.PublicKey, outsider.Sign(serMsg)), ErrTransactionAddNotNecessarySignatures)
+	assert.NoError(t, handOff.AddSignatureForPubkey(custodian2.PublicKey, custodian2.Sign(serMsg)))
+	assert.NoError(t, handOff.AddSignatureForPubkey(feePayer.PublicKey, feePayer.Sign(serMsg)))
+	assert.Empty(t, handOff.MissingSigners())
+	assert.NoError(t, handOff.VerifySignatures())
+	assert.Equal(t, Transaction{
+		Signatures: []Signature{feePayer.Sign(serMsg), custodian1.Sign(serMsg), custodian2.Sign(serMsg)},
+		Message:    msg,
+	}, handOff)
+
+	// a tampered signature is caught
+	tampered := Transaction{
+		Signatures: []Signature{feePayer.Sign(serMsg), custodian2.Sign(serMsg)},
+		Message:    msg,
+	}
+	assert.ErrorIs(t, tampered.VerifySignatures(), ErrTransactionInvalidSignature)
+	_, err = tampered.SerializePartiallySigned()
+	assert.ErrorIs(t, err, ErrTransactionInvalidSignature)
+
+	// slots not reserved yet are zeroed
+	short := Transaction{Signatures: []Signature{feePayer.Sign(serMsg)}, Message: msg}
+	assert.Equal(t, []common.PublicKey{custodian1.PublicKey, custodian2.PublicKey}, short.MissingSigners())
+	raw, err = short.SerializePartiallySigned()
+	assert.NoError(t, err)
+	assert.Equal(t, Transaction{
+		Signatures: []Signature{feePayer.Sign(serMsg), make([]byte, 64), make([]byte, 64)},
+		Message:    msg,
+	}, MustTransactionDeserialize(raw))
+}
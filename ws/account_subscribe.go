@@ -0,0 +1,25 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+type AccountNotification rpc.ValueWithContext[rpc.AccountInfo]
+
+// AccountSubscribeConfig is an option config for `accountSubscribe`
+type AccountSubscribeConfig struct {
+	Commitment rpc.Commitment      `json:"commitment,omitempty"`
+	Encoding   rpc.AccountEncoding `json:"encoding,omitempty"`
+}
+
+// AccountSubscribe receives a notification when the lamports or data of the account change
+func (c *Client) AccountSubscribe(ctx context.Context, base58Addr string) (*Subscription[AccountNotification], error) {
+	return subscribe(ctx, c, "accountSubscribe", "accountUnsubscribe", []any{base58Addr}, decodeJSON[AccountNotification])
+}
+
+// AccountSubscribeWithConfig receives a notification when the lamports or data of the account change
+func (c *Client) AccountSubscribeWithConfig(ctx context.Context, base58Addr string, cfg AccountSubscribeConfig) (*Subscription[AccountNotification], error) {
+	return subscribe(ctx, c, "accountSubscribe", "accountUnsubscribe", []any{base58Addr, cfg}, decodeJSON[AccountNotification])
+}
@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+func TestAccountSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[AccountNotification]{
+		{
+			F: func(c *Client) (*Subscription[AccountNotification], error) {
+				return c.AccountSubscribe(context.Background(), "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+			},
+			ExpectedMethod:     "accountSubscribe",
+			ExpectedParams:     `["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"]`,
+			NotificationMethod: "accountNotification",
+			Notification:       `{"context":{"slot":5199307},"value":{"data":"","executable":false,"lamports":33594,"owner":"11111111111111111111111111111111","rentEpoch":635}}`,
+			ExpectedValue: AccountNotification{
+				Context: rpc.Context{
+					Slot: 5199307,
+				},
+				Value: rpc.AccountInfo{
+					Lamports:  33594,
+					Owner:     "11111111111111111111111111111111",
+					RentEpoch: 635,
+					Data:      "",
+				},
+			},
+		},
+		{
+			F: func(c *Client) (*Subscription[AccountNotification], error) {
+				return c.AccountSubscribeWithConfig(
+					context.Background(),
+					"RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7",
+					AccountSubscribeConfig{
+						Commitment: rpc.CommitmentFinalized,
+						Encoding:   rpc.AccountEncodingBase64,
+					},
+				)
+			},
+			ExpectedMethod:     "accountSubscribe",
+			ExpectedParams:     `["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7",{"commitment":"finalized","encoding":"base64"}]`,
+			NotificationMethod: "accountNotification",
+			Notification:       `{"context":{"slot":5199307},"value":{"data":["","base64"],"executable":false,"lamports":33594,"owner":"11111111111111111111111111111111","rentEpoch":635}}`,
+			ExpectedValue: AccountNotification{
+				Context: rpc.Context{
+					Slot: 5199307,
+				},
+				Value: rpc.AccountInfo{
+					Lamports:  33594,
+					Owner:     "11111111111111111111111111111111",
+					RentEpoch: 635,
+					Data:      []any{"", "base64"},
+				},
+			},
+		},
+	})
+}
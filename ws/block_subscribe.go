@@ -0,0 +1,47 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+type BlockNotification rpc.ValueWithContext[BlockNotificationValue]
+
+type BlockNotificationValue struct {
+	Slot  uint64        `json:"slot"`
+	Err   any           `json:"err"`
+	Block *rpc.GetBlock `json:"block"`
+}
+
+// BlockSubscribeFilter is BlockSubscribeFilterAll or the value returned by
+// BlockSubscribeFilterMentionsAccountOrProgram
+type BlockSubscribeFilter any
+
+const BlockSubscribeFilterAll = "all"
+
+// BlockSubscribeFilterMentionsAccountOrProgram only receives blocks with transactions which mention the address
+func BlockSubscribeFilterMentionsAccountOrProgram(base58Addr string) BlockSubscribeFilter {
+	return map[string]string{"mentionsAccountOrProgram": base58Addr}
+}
+
+// BlockSubscribeConfig is an option config for `blockSubscribe`
+type BlockSubscribeConfig struct {
+	Commitment                     rpc.Commitment                       `json:"commitment,omitempty"`
+	Encoding                       rpc.GetBlockConfigEncoding           `json:"encoding,omitempty"`
+	TransactionDetails             rpc.GetBlockConfigTransactionDetails `json:"transactionDetails,omitempty"`
+	ShowRewards                    *bool                                `json:"showRewards,omitempty"`
+	MaxSupportedTransactionVersion *uint8                               `json:"maxSupportedTransactionVersion,omitempty"`
+}
+
+// BlockSubscribe receives a notification anytime a new block is confirmed or finalized.
+// the node has to enable `--rpc-pubsub-enable-block-subscription`
+func (c *Client) BlockSubscribe(ctx context.Context, filter BlockSubscribeFilter) (*Subscription[BlockNotification], error) {
+	return subscribe(ctx, c, "blockSubscribe", "blockUnsubscribe", []any{filter}, decodeJSON[BlockNotification])
+}
+
+// BlockSubscribeWithConfig receives a notification anytime a new block is confirmed or finalized.
+// the node has to enable `--rpc-pubsub-enable-block-subscription`
+func (c *Client) BlockSubscribeWithConfig(ctx context.Context, filter BlockSubscribeFilter, cfg BlockSubscribeConfig) (*Subscription[BlockNotification], error) {
+	return subscribe(ctx, c, "blockSubscribe", "blockUnsubscribe", []any{filter, cfg}, decodeJSON[BlockNotification])
+}
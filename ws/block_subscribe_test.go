@@ -0,0 +1,49 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/pkg/pointer"
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+func TestBlockSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[BlockNotification]{
+		{
+			F: func(c *Client) (*Subscription[BlockNotification], error) {
+				return c.BlockSubscribeWithConfig(
+					context.Background(),
+					BlockSubscribeFilterMentionsAccountOrProgram("LieKvPRE8XeX3Y2xVNHjKlpAScD12lYySBVQ4HqoJ5op"),
+					BlockSubscribeConfig{
+						Commitment:                     rpc.CommitmentConfirmed,
+						Encoding:                       rpc.GetBlockConfigEncodingBase64,
+						TransactionDetails:             rpc.GetBlockConfigTransactionDetailsSignatures,
+						ShowRewards:                    pointer.Get(false),
+						MaxSupportedTransactionVersion: pointer.Get[uint8](0),
+					},
+				)
+			},
+			ExpectedMethod:     "blockSubscribe",
+			ExpectedParams:     `[{"mentionsAccountOrProgram":"LieKvPRE8XeX3Y2xVNHjKlpAScD12lYySBVQ4HqoJ5op"},{"commitment":"confirmed","encoding":"base64","transactionDetails":"signatures","showRewards":false,"maxSupportedTransactionVersion":0}]`,
+			NotificationMethod: "blockNotification",
+			Notification:       `{"context":{"slot":112301554},"value":{"slot":112301554,"block":{"previousBlockhash":"GJp125YAN4ufCSUvZJVdCyWQJ7RPWMmwxoyUQySydZA","blockhash":"6ojMHjctdqfB55JDpEpqfHnP96fiaHEcvzEQ2NNcxzHP","parentSlot":112301553,"signatures":["5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv"],"blockTime":1639926816,"blockHeight":101210751},"err":null}}`,
+			ExpectedValue: BlockNotification{
+				Context: rpc.Context{
+					Slot: 112301554,
+				},
+				Value: BlockNotificationValue{
+					Slot: 112301554,
+					Block: &rpc.GetBlock{
+						PreviousBlockhash: "GJp125YAN4ufCSUvZJVdCyWQJ7RPWMmwxoyUQySydZA",
+						Blockhash:         "6ojMHjctdqfB55JDpEpqfHnP96fiaHEcvzEQ2NNcxzHP",
+						ParentSlot:        112301553,
+						Signatures:        []string{"5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv"},
+						BlockTime:         pointer.Get[int64](1639926816),
+						BlockHeight:       pointer.Get[int64](101210751),
+					},
+				},
+			},
+		},
+	})
+}
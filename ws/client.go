@@ -0,0 +1,388 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/internal/websocket"
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+const (
+	LocalnetWSEndpoint = "ws://localhost:8900"
+	DevnetWSEndpoint   = "wss://api.devnet.solana.com"
+	TestnetWSEndpoint  = "wss://api.testnet.solana.com"
+	MainnetWSEndpoint  = "wss://api.mainnet-beta.solana.com"
+)
+
+var (
+	ErrClientClosed   = errors.New("ws: client closed")
+	ErrConnectionLost = errors.New("ws: connection lost")
+	// ErrSlowConsumer means a notification arrived while the buffer of the
+	// subscription was full. the subscription is closed and unsubscribed so it
+	// can't hold up other subscriptions sharing the connection.
+	ErrSlowConsumer = errors.New("ws: subscription buffer is full")
+)
+
+// Client is a pubsub client. it keeps a single websocket connection, reconnects
+// when the connection drops and resubscribes every active subscription.
+type Client struct {
+	endpoint          string
+	header            http.Header
+	reconnectInterval time.Duration
+	maxReconnectDelay time.Duration
+	pingInterval      time.Duration
+	pongTimeout       time.Duration
+	bufferSize        int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	requestId uint64
+	pending   map[uint64]func(json.RawMessage, error)
+	subs      map[*subscription]struct{}
+	serverIds map[uint64]*subscription
+}
+
+// Connect dials the endpoint and starts the background read loop
+func Connect(ctx context.Context, endpoint string, opts ...Option) (*Client, error) {
+	c := &Client{
+		endpoint:  endpoint,
+		pending:   map[uint64]func(json.RawMessage, error){},
+		subs:      map[*subscription]struct{}{},
+		serverIds: map[uint64]*subscription{},
+		done:      make(chan struct{}),
+	}
+	setDefaultOptions(c)
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	conn, err := websocket.Dial(ctx, c.endpoint, c.header)
+	if err != nil {
+		return nil, fmt.Errorf("ws: failed to connect, err: %v", err)
+	}
+	c.conn = conn
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	go c.run(conn)
+	if c.pingInterval > 0 {
+		go c.keepalive()
+	}
+
+	return c, nil
+}
+
+// Close stops reconnecting and closes every subscription
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return nil
+	}
+	c.cancel()
+	conn := c.conn
+	c.mu.Unlock()
+
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+	<-c.done
+	return err
+}
+
+type message struct {
+	Id     *uint64           `json:"id"`
+	Result json.RawMessage   `json:"result"`
+	Error  *rpc.JsonRpcError `json:"error"`
+	Method string            `json:"method"`
+	Params *struct {
+		Result       json.RawMessage `json:"result"`
+		Subscription uint64          `json:"subscription"`
+	} `json:"params"`
+}
+
+func (c *Client) run(conn *websocket.Conn) {
+	defer close(c.done)
+	for {
+		c.readLoop(conn)
+
+		c.mu.Lock()
+		c.conn = nil
+		c.serverIds = map[uint64]*subscription{}
+		pending := c.pending
+		c.pending = map[uint64]func(json.RawMessage, error){}
+		c.mu.Unlock()
+		for _, f := range pending {
+			f(nil, ErrConnectionLost)
+		}
+
+		conn = c.reconnect()
+		if conn == nil {
+			c.closeAll(ErrClientClosed)
+			return
+		}
+	}
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	for {
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			conn.Close()
+			return
+		}
+
+		var msg message
+		if err := json.Unmarshal(b, &msg); err != nil {
+			continue
+		}
+
+		switch {
+		case msg.Id != nil:
+			c.mu.Lock()
+			f, ok := c.pending[*msg.Id]
+			delete(c.pending, *msg.Id)
+			c.mu.Unlock()
+			if !ok {
+				continue
+			}
+			if msg.Error != nil {
+				f(nil, msg.Error)
+			} else {
+				f(msg.Result, nil)
+			}
+		case msg.Params != nil:
+			c.mu.Lock()
+			sub, ok := c.serverIds[msg.Params.Subscription]
+			c.mu.Unlock()
+			if !ok {
+				continue
+			}
+			finished, err := sub.deliver(msg.Params.Result)
+			if finished || err != nil {
+				c.mu.Lock()
+				delete(c.subs, sub)
+				delete(c.serverIds, msg.Params.Subscription)
+				if err != nil {
+					// the server only ends a subscription by itself when it's finished
+					c.sendLocked(sub.unsubscribeMethod, []any{msg.Params.Subscription}, func(json.RawMessage, error) {})
+				}
+				c.mu.Unlock()
+				sub.close(err)
+			}
+		}
+	}
+}
+
+// reconnect blocks until a new connection is established and every subscription
+// is sent again. it returns nil if the client has been closed.
+func (c *Client) reconnect() *websocket.Conn {
+	delay := c.reconnectInterval
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		conn, err := websocket.Dial(c.ctx, c.endpoint, c.header)
+		if err != nil {
+			delay *= 2
+			if delay > c.maxReconnectDelay {
+				delay = c.maxReconnectDelay
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		if c.ctx.Err() != nil {
+			c.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		c.conn = conn
+		for sub := range c.subs {
+			c.sendSubscribeLocked(sub)
+		}
+		c.mu.Unlock()
+		return conn
+	}
+}
+
+// keepalive pings the server and closes the connection if nothing, not even the
+// pong, arrives within the pong timeout. a half-open connection never fails the
+// write, only the missing pong tells it's dead.
+func (c *Client) keepalive() {
+	t := time.NewTicker(c.pingInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+		if conn == nil {
+			continue
+		}
+		sent := time.Now()
+		if conn.WriteMessage(websocket.PingMessage, nil) != nil {
+			conn.Close()
+			continue
+		}
+		if c.pongTimeout <= 0 {
+			continue
+		}
+
+		timeout := time.NewTimer(c.pongTimeout)
+		select {
+		case <-c.ctx.Done():
+			timeout.Stop()
+			return
+		case <-timeout.C:
+		}
+		if conn.LastRead().Before(sent) {
+			conn.Close()
+		}
+	}
+}
+
+func (c *Client) closeAll(err error) {
+	c.mu.Lock()
+	subs := c.subs
+	c.subs = map[*subscription]struct{}{}
+	c.mu.Unlock()
+	for sub := range subs {
+		sub.close(err)
+	}
+}
+
+// sendLocked writes a request and registers f as the response handler. the
+// caller must hold c.mu. if the connection is down, f gets ErrConnectionLost.
+func (c *Client) sendLocked(method string, params []any, f func(json.RawMessage, error)) {
+	if c.conn == nil {
+		go f(nil, ErrConnectionLost)
+		return
+	}
+
+	c.requestId++
+	id := c.requestId
+	b, err := json.Marshal(rpc.JsonRpcRequest{
+		JsonRpc: "2.0",
+		Id:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		go f(nil, fmt.Errorf("failed to marshal request, err: %v", err))
+		return
+	}
+
+	c.pending[id] = f
+	if err := c.conn.WriteMessage(websocket.TextMessage, b); err != nil {
+		// the read loop will notice the broken connection and fail the handler
+		c.conn.Close()
+	}
+}
+
+func (c *Client) sendSubscribeLocked(sub *subscription) {
+	c.sendLocked(sub.method, sub.params, func(result json.RawMessage, err error) {
+		if errors.Is(err, ErrConnectionLost) {
+			// it will be sent again after reconnecting
+			return
+		}
+		if err == nil {
+			var serverId uint64
+			if err = json.Unmarshal(result, &serverId); err == nil {
+				c.mu.Lock()
+				if _, ok := c.subs[sub]; ok {
+					sub.serverId = serverId
+					c.serverIds[serverId] = sub
+				} else {
+					// it was unsubscribed before the server answered
+					c.sendLocked(sub.unsubscribeMethod, []any{serverId}, func(json.RawMessage, error) {})
+				}
+				c.mu.Unlock()
+				sub.subscribed(nil)
+				return
+			}
+			err = fmt.Errorf("failed to parse subscription id, err: %v", err)
+		}
+		c.mu.Lock()
+		delete(c.subs, sub)
+		c.mu.Unlock()
+		sub.subscribed(err)
+		sub.close(err)
+	})
+}
+
+func (c *Client) subscribe(ctx context.Context, sub *subscription) error {
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return ErrClientClosed
+	}
+	c.subs[sub] = struct{}{}
+	if c.conn != nil {
+		c.sendSubscribeLocked(sub)
+	}
+	c.mu.Unlock()
+
+	select {
+	case err := <-sub.ready:
+		return err
+	case <-ctx.Done():
+		c.unsubscribe(context.Background(), sub)
+		return ctx.Err()
+	}
+}
+
+func (c *Client) unsubscribe(ctx context.Context, sub *subscription) error {
+	c.mu.Lock()
+	if _, ok := c.subs[sub]; !ok {
+		c.mu.Unlock()
+		return nil
+	}
+	delete(c.subs, sub)
+	serverId, active := sub.serverId, c.serverIds[sub.serverId] == sub
+	if active {
+		delete(c.serverIds, serverId)
+	}
+	c.mu.Unlock()
+	sub.close(nil)
+
+	if !active {
+		return nil
+	}
+
+	errCh := make(chan error, 1)
+	c.mu.Lock()
+	c.sendLocked(sub.unsubscribeMethod, []any{serverId}, func(_ json.RawMessage, err error) {
+		errCh <- err
+	})
+	c.mu.Unlock()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, ErrConnectionLost) {
+			// the server drops the subscription with the connection
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,329 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is a local stand-in for the solana pubsub endpoint. every subscribe
+// request gets a new subscription id and is forwarded to requests.
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	conns    []*websocket.Conn
+	nextId   uint64
+	requests chan testRequest
+	// hold delays subscribe responses until it's closed if it's set
+	hold chan struct{}
+}
+
+type testRequest struct {
+	Id             uint64          `json:"id"`
+	Method         string          `json:"method"`
+	Params         json.RawMessage `json:"params"`
+	SubscriptionId uint64          `json:"-"`
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{requests: make(chan testRequest, 16)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		for {
+			_, b, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req testRequest
+			if !assert.NoError(t, json.Unmarshal(b, &req)) {
+				return
+			}
+
+			if s.hold != nil && strings.HasSuffix(req.Method, "Subscribe") {
+				<-s.hold
+			}
+
+			s.mu.Lock()
+			var result any = true
+			if strings.HasSuffix(req.Method, "Subscribe") {
+				s.nextId++
+				req.SubscriptionId = s.nextId
+				result = s.nextId
+			}
+			s.mu.Unlock()
+
+			b, _ = json.Marshal(map[string]any{"jsonrpc": "2.0", "result": result, "id": req.Id})
+			assert.NoError(t, conn.WriteMessage(websocket.TextMessage, b))
+			s.requests <- req
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *testServer) notify(t *testing.T, method string, subscriptionId uint64, result string) {
+	s.mu.Lock()
+	conn := s.conns[len(s.conns)-1]
+	s.mu.Unlock()
+	msg := `{"jsonrpc":"2.0","method":"` + method + `","params":{"result":` + result + `,"subscription":` + jsonUint(subscriptionId) + `}}`
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(msg)))
+}
+
+// dropConnections closes every connection from the server side
+func (s *testServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *testServer) nextRequest(t *testing.T) testRequest {
+	select {
+	case req := <-s.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for request")
+		return testRequest{}
+	}
+}
+
+func jsonUint(v uint64) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func recv[T any](t *testing.T, sub *Subscription[T]) T {
+	select {
+	case v, ok := <-sub.Notifications():
+		require.True(t, ok, "channel closed")
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for notification")
+		var v T
+		return v
+	}
+}
+
+type subscribeParam[T any] struct {
+	Name               string
+	F                  func(c *Client) (*Subscription[T], error)
+	ExpectedMethod     string
+	ExpectedParams     string
+	NotificationMethod string
+	Notification       string
+	ExpectedValue      T
+}
+
+func testSubscribe[T any](t *testing.T, params []subscribeParam[T]) {
+	for _, param := range params {
+		t.Run(param.Name, func(t *testing.T) {
+			s := newTestServer(t)
+			c, err := Connect(context.Background(), s.url())
+			require.NoError(t, err)
+			defer c.Close()
+
+			sub, err := param.F(c)
+			require.NoError(t, err)
+
+			req := s.nextRequest(t)
+			assert.Equal(t, param.ExpectedMethod, req.Method)
+			if param.ExpectedParams == "" {
+				assert.Empty(t, req.Params)
+			} else {
+				assert.JSONEq(t, param.ExpectedParams, string(req.Params))
+			}
+
+			s.notify(t, param.NotificationMethod, req.SubscriptionId, param.Notification)
+			assert.Equal(t, param.ExpectedValue, recv(t, sub))
+		})
+	}
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	s := newTestServer(t)
+	c, err := Connect(context.Background(), s.url(), WithReconnectInterval(10*time.Millisecond, 50*time.Millisecond))
+	require.NoError(t, err)
+	defer c.Close()
+
+	sub, err := c.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	req := s.nextRequest(t)
+	s.notify(t, "slotNotification", req.SubscriptionId, `{"parent":1,"root":0,"slot":2}`)
+	assert.Equal(t, SlotNotification{Parent: 1, Root: 0, Slot: 2}, recv(t, sub))
+
+	s.dropConnections()
+
+	// the client should resubscribe with the same request and use the new id
+	req = s.nextRequest(t)
+	assert.Equal(t, "slotSubscribe", req.Method)
+	assert.Equal(t, uint64(2), req.SubscriptionId)
+	s.notify(t, "slotNotification", req.SubscriptionId, `{"parent":2,"root":1,"slot":3}`)
+	assert.Equal(t, SlotNotification{Parent: 2, Root: 1, Slot: 3}, recv(t, sub))
+}
+
+func TestClient_Unsubscribe(t *testing.T) {
+	s := newTestServer(t)
+	c, err := Connect(context.Background(), s.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	sub, err := c.RootSubscribe(context.Background())
+	require.NoError(t, err)
+	req := s.nextRequest(t)
+
+	require.NoError(t, sub.Unsubscribe(context.Background()))
+	unsubscribeReq := s.nextRequest(t)
+	assert.Equal(t, "rootUnsubscribe", unsubscribeReq.Method)
+	assert.JSONEq(t, "["+jsonUint(req.SubscriptionId)+"]", string(unsubscribeReq.Params))
+
+	_, ok := <-sub.Notifications()
+	assert.False(t, ok)
+}
+
+func TestClient_Close(t *testing.T) {
+	s := newTestServer(t)
+	c, err := Connect(context.Background(), s.url())
+	require.NoError(t, err)
+
+	sub, err := c.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	s.nextRequest(t)
+
+	require.NoError(t, c.Close())
+	_, ok := <-sub.Notifications()
+	assert.False(t, ok)
+	assert.ErrorIs(t, <-sub.Err(), ErrClientClosed)
+
+	_, err = c.SlotSubscribe(context.Background())
+	assert.ErrorIs(t, err, ErrClientClosed)
+}
+
+func TestClient_SlowConsumer(t *testing.T) {
+	s := newTestServer(t)
+	c, err := Connect(context.Background(), s.url(), WithBufferSize(1))
+	require.NoError(t, err)
+	defer c.Close()
+
+	slow, err := c.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	slowReq := s.nextRequest(t)
+	root, err := c.RootSubscribe(context.Background())
+	require.NoError(t, err)
+	rootReq := s.nextRequest(t)
+
+	// the second notification doesn't fit in the buffer
+	s.notify(t, "slotNotification", slowReq.SubscriptionId, `{"parent":1,"root":0,"slot":2}`)
+	s.notify(t, "slotNotification", slowReq.SubscriptionId, `{"parent":2,"root":1,"slot":3}`)
+
+	unsubscribeReq := s.nextRequest(t)
+	assert.Equal(t, "slotUnsubscribe", unsubscribeReq.Method)
+	assert.JSONEq(t, "["+jsonUint(slowReq.SubscriptionId)+"]", string(unsubscribeReq.Params))
+	assert.ErrorIs(t, <-slow.Err(), ErrSlowConsumer)
+	assert.Equal(t, SlotNotification{Parent: 1, Root: 0, Slot: 2}, recv(t, slow))
+	_, ok := <-slow.Notifications()
+	assert.False(t, ok)
+
+	// other subscriptions keep receiving
+	s.notify(t, "rootNotification", rootReq.SubscriptionId, `42`)
+	assert.Equal(t, RootNotification(42), recv(t, root))
+}
+
+func TestClient_UnsubscribeBeforeSubscribed(t *testing.T) {
+	s := newTestServer(t)
+	s.hold = make(chan struct{})
+	c, err := Connect(context.Background(), s.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.RootSubscribe(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	close(s.hold)
+
+	// the server answers the cancelled subscribe, the client should drop it on the server
+	req := s.nextRequest(t)
+	assert.Equal(t, "rootSubscribe", req.Method)
+	unsubscribeReq := s.nextRequest(t)
+	assert.Equal(t, "rootUnsubscribe", unsubscribeReq.Method)
+	assert.JSONEq(t, "["+jsonUint(req.SubscriptionId)+"]", string(unsubscribeReq.Params))
+}
+
+func TestClient_PongTimeout(t *testing.T) {
+	// the first connection never reads, so pings stay unanswered like on a
+	// half-open connection
+	accepted := make(chan struct{}, 2)
+	stop := make(chan struct{})
+	var n int
+	var mu sync.Mutex
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		accepted <- struct{}{}
+		mu.Lock()
+		n++
+		first := n == 1
+		mu.Unlock()
+		if first {
+			<-stop
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer s.Close()
+	defer close(stop)
+
+	c, err := Connect(
+		context.Background(),
+		"ws"+strings.TrimPrefix(s.URL, "http"),
+		WithPingInterval(20*time.Millisecond),
+		WithPongTimeout(20*time.Millisecond),
+		WithReconnectInterval(10*time.Millisecond, 50*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-accepted:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for connection")
+		}
+	}
+
+	// the second connection answers pings and stays
+	select {
+	case <-accepted:
+		t.Fatal("unexpected reconnect")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
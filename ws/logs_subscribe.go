@@ -0,0 +1,44 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+type LogsNotification rpc.ValueWithContext[LogsNotificationValue]
+
+type LogsNotificationValue struct {
+	Signature string   `json:"signature"`
+	Err       any      `json:"err"`
+	Logs      []string `json:"logs"`
+}
+
+// LogsSubscribeFilter is one of LogsSubscribeFilterAll, LogsSubscribeFilterAllWithVotes
+// or the value returned by LogsSubscribeFilterMentions
+type LogsSubscribeFilter any
+
+const (
+	LogsSubscribeFilterAll          = "all"
+	LogsSubscribeFilterAllWithVotes = "allWithVotes"
+)
+
+// LogsSubscribeFilterMentions only receives logs of transactions which mention the address
+func LogsSubscribeFilterMentions(base58Addr string) LogsSubscribeFilter {
+	return map[string][]string{"mentions": {base58Addr}}
+}
+
+// LogsSubscribeConfig is an option config for `logsSubscribe`
+type LogsSubscribeConfig struct {
+	Commitment rpc.Commitment `json:"commitment,omitempty"`
+}
+
+// LogsSubscribe receives transaction logging
+func (c *Client) LogsSubscribe(ctx context.Context, filter LogsSubscribeFilter) (*Subscription[LogsNotification], error) {
+	return subscribe(ctx, c, "logsSubscribe", "logsUnsubscribe", []any{filter}, decodeJSON[LogsNotification])
+}
+
+// LogsSubscribeWithConfig receives transaction logging
+func (c *Client) LogsSubscribeWithConfig(ctx context.Context, filter LogsSubscribeFilter, cfg LogsSubscribeConfig) (*Subscription[LogsNotification], error) {
+	return subscribe(ctx, c, "logsSubscribe", "logsUnsubscribe", []any{filter, cfg}, decodeJSON[LogsNotification])
+}
@@ -0,0 +1,57 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+func TestLogsSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[LogsNotification]{
+		{
+			F: func(c *Client) (*Subscription[LogsNotification], error) {
+				return c.LogsSubscribe(context.Background(), LogsSubscribeFilterAll)
+			},
+			ExpectedMethod:     "logsSubscribe",
+			ExpectedParams:     `["all"]`,
+			NotificationMethod: "logsNotification",
+			Notification:       `{"context":{"slot":5208469},"value":{"signature":"5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv","err":null,"logs":["Program 11111111111111111111111111111111 invoke [1]","Program 11111111111111111111111111111111 success"]}}`,
+			ExpectedValue: LogsNotification{
+				Context: rpc.Context{
+					Slot: 5208469,
+				},
+				Value: LogsNotificationValue{
+					Signature: "5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv",
+					Logs: []string{
+						"Program 11111111111111111111111111111111 invoke [1]",
+						"Program 11111111111111111111111111111111 success",
+					},
+				},
+			},
+		},
+		{
+			F: func(c *Client) (*Subscription[LogsNotification], error) {
+				return c.LogsSubscribeWithConfig(
+					context.Background(),
+					LogsSubscribeFilterMentions("11111111111111111111111111111111"),
+					LogsSubscribeConfig{Commitment: rpc.CommitmentConfirmed},
+				)
+			},
+			ExpectedMethod:     "logsSubscribe",
+			ExpectedParams:     `[{"mentions":["11111111111111111111111111111111"]},{"commitment":"confirmed"}]`,
+			NotificationMethod: "logsNotification",
+			Notification:       `{"context":{"slot":5208469},"value":{"signature":"5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv","err":{"InstructionError":[0,{"Custom":1}]},"logs":[]}}`,
+			ExpectedValue: LogsNotification{
+				Context: rpc.Context{
+					Slot: 5208469,
+				},
+				Value: LogsNotificationValue{
+					Signature: "5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv",
+					Err:       map[string]any{"InstructionError": []any{float64(0), map[string]any{"Custom": float64(1)}}},
+					Logs:      []string{},
+				},
+			},
+		},
+	})
+}
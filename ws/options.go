@@ -0,0 +1,58 @@
+package ws
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option is a configuration type for the Client
+type Option func(*Client)
+
+// WithHeader sets extra http headers used by the websocket handshake
+func WithHeader(h http.Header) Option {
+	return func(c *Client) {
+		c.header = h
+	}
+}
+
+// WithReconnectInterval sets the initial and the maximum delay between
+// reconnect attempts. the delay doubles after every failed attempt.
+func WithReconnectInterval(initial, max time.Duration) Option {
+	return func(c *Client) {
+		c.reconnectInterval = initial
+		c.maxReconnectDelay = max
+	}
+}
+
+// WithPingInterval sets how often a ping frame is sent to detect a dead
+// connection. zero disables it.
+func WithPingInterval(d time.Duration) Option {
+	return func(c *Client) {
+		c.pingInterval = d
+	}
+}
+
+// WithPongTimeout sets how long the client waits for a pong, or any other frame,
+// after a ping before it drops the connection and reconnects. zero disables it.
+func WithPongTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.pongTimeout = d
+	}
+}
+
+// WithBufferSize sets the channel buffer size of each subscription. a subscription
+// whose buffer is full when a notification arrives is closed with ErrSlowConsumer.
+func WithBufferSize(n int) Option {
+	return func(c *Client) {
+		c.bufferSize = n
+	}
+}
+
+func setDefaultOptions(c *Client) {
+	c.header = http.Header{}
+	c.reconnectInterval = 500 * time.Millisecond
+	c.maxReconnectDelay = 30 * time.Second
+	c.pingInterval = 30 * time.Second
+	c.pongTimeout = 10 * time.Second
+	c.bufferSize = 64
+}
@@ -0,0 +1,45 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+func TestProgramSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[ProgramNotification]{
+		{
+			F: func(c *Client) (*Subscription[ProgramNotification], error) {
+				return c.ProgramSubscribeWithConfig(
+					context.Background(),
+					"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+					ProgramSubscribeConfig{
+						Encoding: rpc.AccountEncodingBase64,
+						Filters: []rpc.GetProgramAccountsConfigFilter{
+							{DataSize: 165},
+						},
+					},
+				)
+			},
+			ExpectedMethod:     "programSubscribe",
+			ExpectedParams:     `["TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",{"encoding":"base64","filters":[{"dataSize":165}]}]`,
+			NotificationMethod: "programNotification",
+			Notification:       `{"context":{"slot":5208469},"value":{"pubkey":"H4vnBqifaSACnKa7acsxstsY1iV1bvJNxsCY7enrd1hq","account":{"data":["","base64"],"executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":636}}}`,
+			ExpectedValue: ProgramNotification{
+				Context: rpc.Context{
+					Slot: 5208469,
+				},
+				Value: rpc.GetProgramAccount{
+					Pubkey: "H4vnBqifaSACnKa7acsxstsY1iV1bvJNxsCY7enrd1hq",
+					Account: rpc.AccountInfo{
+						Lamports:  2039280,
+						Owner:     "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+						RentEpoch: 636,
+						Data:      []any{"", "base64"},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,11 @@
+package ws
+
+import "context"
+
+// RootNotification is the latest root slot number
+type RootNotification uint64
+
+// RootSubscribe receives a notification anytime a new root is set by the validator
+func (c *Client) RootSubscribe(ctx context.Context) (*Subscription[RootNotification], error) {
+	return subscribe(ctx, c, "rootSubscribe", "rootUnsubscribe", nil, decodeJSON[RootNotification])
+}
@@ -0,0 +1,20 @@
+package ws
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRootSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[RootNotification]{
+		{
+			F: func(c *Client) (*Subscription[RootNotification], error) {
+				return c.RootSubscribe(context.Background())
+			},
+			ExpectedMethod:     "rootSubscribe",
+			NotificationMethod: "rootNotification",
+			Notification:       `42`,
+			ExpectedValue:      RootNotification(42),
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+)
+
+type SignatureNotification rpc.ValueWithContext[SignatureNotificationValue]
+
+type SignatureNotificationValue struct {
+	// ReceivedSignature is true for the notification sent when the node receives
+	// the signature, it only happens if EnableReceivedNotification is set
+	ReceivedSignature bool
+	Err               any
+}
+
+func (v *SignatureNotificationValue) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		v.ReceivedSignature = s == "receivedSignature"
+		return nil
+	}
+	var r struct {
+		Err any `json:"err"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return err
+	}
+	v.Err = r.Err
+	return nil
+}
+
+// SignatureSubscribeConfig is an option config for `signatureSubscribe`
+type SignatureSubscribeConfig struct {
+	Commitment                 rpc.Commitment `json:"commitment,omitempty"`
+	EnableReceivedNotification bool           `json:"enableReceivedNotification,omitempty"`
+}
+
+// SignatureSubscribe receives a notification when the transaction reaches the commitment.
+// the server cancels the subscription after that, so does the channel get closed.
+func (c *Client) SignatureSubscribe(ctx context.Context, signature string) (*Subscription[SignatureNotification], error) {
+	return subscribe(ctx, c, "signatureSubscribe", "signatureUnsubscribe", []any{signature}, decodeSignatureNotification)
+}
+
+// SignatureSubscribeWithConfig receives a notification when the transaction reaches the commitment.
+// the server cancels the subscription after that, so does the channel get closed.
+func (c *Client) SignatureSubscribeWithConfig(ctx context.Context, signature string, cfg SignatureSubscribeConfig) (*Subscription[SignatureNotification], error) {
+	return subscribe(ctx, c, "signatureSubscribe", "signatureUnsubscribe", []any{signature, cfg}, decodeSignatureNotification)
+}
+
+func decodeSignatureNotification(result json.RawMessage) (SignatureNotification, bool, error) {
+	v, _, err := decodeJSON[SignatureNotification](result)
+	if err != nil {
+		return v, false, err
+	}
+	return v, !v.Value.ReceivedSignature, nil
+}
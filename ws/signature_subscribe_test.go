@@ -0,0 +1,75 @@
+package ws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[SignatureNotification]{
+		{
+			F: func(c *Client) (*Subscription[SignatureNotification], error) {
+				return c.SignatureSubscribe(context.Background(), "2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b")
+			},
+			ExpectedMethod:     "signatureSubscribe",
+			ExpectedParams:     `["2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b"]`,
+			NotificationMethod: "signatureNotification",
+			Notification:       `{"context":{"slot":5207624},"value":{"err":null}}`,
+			ExpectedValue: SignatureNotification{
+				Context: rpc.Context{
+					Slot: 5207624,
+				},
+			},
+		},
+		{
+			F: func(c *Client) (*Subscription[SignatureNotification], error) {
+				return c.SignatureSubscribeWithConfig(
+					context.Background(),
+					"2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b",
+					SignatureSubscribeConfig{
+						Commitment:                 rpc.CommitmentFinalized,
+						EnableReceivedNotification: true,
+					},
+				)
+			},
+			ExpectedMethod:     "signatureSubscribe",
+			ExpectedParams:     `["2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b",{"commitment":"finalized","enableReceivedNotification":true}]`,
+			NotificationMethod: "signatureNotification",
+			Notification:       `{"context":{"slot":5207624},"value":"receivedSignature"}`,
+			ExpectedValue: SignatureNotification{
+				Context: rpc.Context{
+					Slot: 5207624,
+				},
+				Value: SignatureNotificationValue{
+					ReceivedSignature: true,
+				},
+			},
+		},
+	})
+}
+
+func TestSignatureSubscribe_ClosedAfterNotification(t *testing.T) {
+	s := newTestServer(t)
+	c, err := Connect(context.Background(), s.url())
+	require.NoError(t, err)
+	defer c.Close()
+
+	sub, err := c.SignatureSubscribe(context.Background(), "2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b")
+	require.NoError(t, err)
+	req := s.nextRequest(t)
+
+	s.notify(t, "signatureNotification", req.SubscriptionId, `{"context":{"slot":5207624},"value":{"err":null}}`)
+	recv(t, sub)
+
+	select {
+	case _, ok := <-sub.Notifications():
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel should be closed")
+	}
+}
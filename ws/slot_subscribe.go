@@ -0,0 +1,14 @@
+package ws
+
+import "context"
+
+type SlotNotification struct {
+	Parent uint64 `json:"parent"`
+	Root   uint64 `json:"root"`
+	Slot   uint64 `json:"slot"`
+}
+
+// SlotSubscribe receives a notification anytime a slot is processed by the validator
+func (c *Client) SlotSubscribe(ctx context.Context) (*Subscription[SlotNotification], error) {
+	return subscribe(ctx, c, "slotSubscribe", "slotUnsubscribe", nil, decodeJSON[SlotNotification])
+}
@@ -0,0 +1,24 @@
+package ws
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSlotSubscribe(t *testing.T) {
+	testSubscribe(t, []subscribeParam[SlotNotification]{
+		{
+			F: func(c *Client) (*Subscription[SlotNotification], error) {
+				return c.SlotSubscribe(context.Background())
+			},
+			ExpectedMethod:     "slotSubscribe",
+			NotificationMethod: "slotNotification",
+			Notification:       `{"parent":75,"root":44,"slot":76}`,
+			ExpectedValue: SlotNotification{
+				Parent: 75,
+				Root:   44,
+				Slot:   76,
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// Subscription delivers typed notifications. the notification channel is closed
+// when the subscription ends, Err reports why it ended.
+type Subscription[T any] struct {
+	client *Client
+	sub    *subscription
+	ch     chan T
+}
+
+// Notifications returns the channel which receives every notification. the read
+// loop never waits for a consumer, if the buffer is full the subscription is
+// closed with ErrSlowConsumer.
+func (s *Subscription[T]) Notifications() <-chan T {
+	return s.ch
+}
+
+// Err returns a channel which receives the error that terminated the
+// subscription, or decode errors of single notifications.
+func (s *Subscription[T]) Err() <-chan error {
+	return s.sub.errCh
+}
+
+// Unsubscribe cancels the subscription on the server and closes the channel
+func (s *Subscription[T]) Unsubscribe(ctx context.Context) error {
+	return s.client.unsubscribe(ctx, s.sub)
+}
+
+type subscription struct {
+	method            string
+	unsubscribeMethod string
+	params            []any
+
+	// guarded by Client.mu
+	serverId uint64
+
+	// handle decodes and forwards a notification, it returns true if no more
+	// notifications are expected. it returns ErrSlowConsumer if the buffer is full.
+	handle func(json.RawMessage) (bool, error)
+
+	ready     chan error
+	readyOnce sync.Once
+	errCh     chan error
+
+	mu      sync.Mutex
+	closed  bool
+	onClose func()
+}
+
+func (s *subscription) subscribed(err error) {
+	s.readyOnce.Do(func() {
+		s.ready <- err
+	})
+}
+
+// deliver returns a non-nil error if the subscription must be closed for it
+func (s *subscription) deliver(result json.RawMessage) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false, nil
+	}
+	finished, err := s.handle(result)
+	if errors.Is(err, ErrSlowConsumer) {
+		return false, err
+	}
+	if err != nil {
+		s.reportLocked(err)
+	}
+	return finished, nil
+}
+
+func (s *subscription) close(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if err != nil {
+		// the terminal error replaces a pending decode error
+		select {
+		case <-s.errCh:
+		default:
+		}
+		s.reportLocked(err)
+	}
+	s.onClose()
+}
+
+func (s *subscription) reportLocked(err error) {
+	select {
+	case s.errCh <- err:
+	default:
+	}
+}
+
+func subscribe[T any](
+	ctx context.Context,
+	c *Client,
+	method, unsubscribeMethod string,
+	params []any,
+	decode func(json.RawMessage) (T, bool, error),
+) (*Subscription[T], error) {
+	ch := make(chan T, c.bufferSize)
+	sub := &subscription{
+		method:            method,
+		unsubscribeMethod: unsubscribeMethod,
+		params:            params,
+		ready:             make(chan error, 1),
+		errCh:             make(chan error, 1),
+		onClose:           func() { close(ch) },
+	}
+	sub.handle = func(result json.RawMessage) (bool, error) {
+		v, finished, err := decode(result)
+		if err != nil {
+			return false, err
+		}
+		select {
+		case ch <- v:
+		default:
+			return false, ErrSlowConsumer
+		}
+		return finished, nil
+	}
+
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &Subscription[T]{client: c, sub: sub, ch: ch}, nil
+}
+
+func decodeJSON[T any](result json.RawMessage) (T, bool, error) {
+	var v T
+	err := json.Unmarshal(result, &v)
+	return v, false, err
+}